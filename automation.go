@@ -0,0 +1,293 @@
+package kooky
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+)
+
+// Format is a cookie serialization format of browser automation tools.
+type Format string
+
+const (
+	FormatNetscape   Format = `netscape`   // cookies.txt as written by ExportCookies()
+	FormatLWP        Format = `lwp`        // Python LWPCookieJar file as written by ExportCookiesLWP()
+	FormatPlaywright Format = `playwright` // Playwright storageState.json
+	FormatPuppeteer  Format = `puppeteer`  // Puppeteer / DevTools Protocol Network.CookieParam[]
+	FormatSelenium   Format = `selenium`   // Selenium / WebDriver JSON cookies
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{FormatNetscape, FormatLWP, FormatPlaywright, FormatPuppeteer, FormatSelenium}
+
+// playwrightStorageState is the format of Playwright's BrowserContext.storageState().
+// https://playwright.dev/docs/api/class-browsercontext#browser-context-storage-state
+type playwrightStorageState struct {
+	Cookies []playwrightCookie `json:"cookies"`
+	Origins []json.RawMessage  `json:"origins"` // localStorage, not handled
+}
+
+type playwrightCookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	Domain   string  `json:"domain"`
+	Path     string  `json:"path"`
+	Expires  float64 `json:"expires"` // -1 for session cookies
+	HTTPOnly bool    `json:"httpOnly"`
+	Secure   bool    `json:"secure"`
+	SameSite string  `json:"sameSite"` // Strict, Lax or None
+}
+
+// puppeteerCookie is the DevTools Protocol Network.CookieParam type used by Puppeteer's page.setCookie().
+// https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-CookieParam
+type puppeteerCookie struct {
+	Name         string   `json:"name"`
+	Value        string   `json:"value"`
+	Domain       string   `json:"domain,omitempty"`
+	Path         string   `json:"path,omitempty"`
+	Secure       bool     `json:"secure,omitempty"`
+	HTTPOnly     bool     `json:"httpOnly,omitempty"`
+	SameSite     string   `json:"sameSite,omitempty"`
+	Expires      *float64 `json:"expires,omitempty"` // missing or -1 for session cookies
+	PartitionKey string   `json:"partitionKey,omitempty"`
+}
+
+// seleniumCookie is the WebDriver cookie object of Selenium's add_cookie() and get_cookies().
+// https://www.w3.org/TR/webdriver2/#cookies
+type seleniumCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Secure   bool   `json:"secure"`
+	HTTPOnly bool   `json:"httpOnly"`
+	Expiry   *int64 `json:"expiry,omitempty"` // missing for session cookies
+	SameSite string `json:"sameSite,omitempty"`
+}
+
+// ExportCookiesFormat() writes the cookies in the format.
+// The JSON formats are written as a whole after the sequence is exhausted.
+func ExportCookiesFormat(ctx context.Context, w io.Writer, format Format, cookies CookieSeq) error {
+	switch format {
+	case FormatNetscape:
+		exportCookieSeq(ctx, w, cookies)
+		return ctx.Err()
+	case FormatLWP:
+		ExportCookiesLWP(ctx, w, cookies)
+		return ctx.Err()
+	}
+	var all Cookies
+	for cookie := range cookies.OnlyCookies() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		all = append(all, cookie)
+	}
+
+	var v any
+	switch format {
+	case FormatPlaywright:
+		st := playwrightStorageState{Cookies: []playwrightCookie{}, Origins: []json.RawMessage{}}
+		for _, c := range all {
+			st.Cookies = append(st.Cookies, toPlaywright(c))
+		}
+		v = st
+	case FormatPuppeteer:
+		cs := []puppeteerCookie{}
+		for _, c := range all {
+			cs = append(cs, toPuppeteer(c))
+		}
+		v = cs
+	case FormatSelenium:
+		cs := []seleniumCookie{}
+		for _, c := range all {
+			cs = append(cs, toSelenium(c))
+		}
+		v = cs
+	default:
+		return fmt.Errorf(`unknown cookie format %q`, format)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent(``, `  `)
+	return enc.Encode(v)
+}
+
+// ImportCookies() reads cookies written in one of the JSON formats.
+func ImportCookies(r io.Reader, format Format) (Cookies, error) {
+	var ret Cookies
+	switch format {
+	case FormatPlaywright:
+		var st playwrightStorageState
+		if err := json.NewDecoder(r).Decode(&st); err != nil {
+			return nil, err
+		}
+		for _, c := range st.Cookies {
+			ret = append(ret, fromPlaywright(c))
+		}
+	case FormatPuppeteer:
+		var cs []puppeteerCookie
+		if err := json.NewDecoder(r).Decode(&cs); err != nil {
+			return nil, err
+		}
+		for _, c := range cs {
+			ret = append(ret, fromPuppeteer(c))
+		}
+	case FormatSelenium:
+		var cs []seleniumCookie
+		if err := json.NewDecoder(r).Decode(&cs); err != nil {
+			return nil, err
+		}
+		for _, c := range cs {
+			ret = append(ret, fromSelenium(c))
+		}
+	case FormatNetscape, FormatLWP:
+		return nil, fmt.Errorf(`use the %s package for reading %[1]s files`, format)
+	default:
+		return nil, fmt.Errorf(`unknown cookie format %q`, format)
+	}
+	return ret, nil
+}
+
+func toPlaywright(c *Cookie) playwrightCookie {
+	p := playwrightCookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		Expires:  -1,
+		HTTPOnly: c.HttpOnly,
+		Secure:   c.Secure,
+		SameSite: sameSiteString(c.SameSite),
+	}
+	if len(p.SameSite) == 0 {
+		// required by Playwright, browser default
+		p.SameSite = `Lax`
+	}
+	if !c.Expires.IsZero() {
+		p.Expires = float64(c.Expires.Unix())
+	}
+	return p
+}
+
+func fromPlaywright(p playwrightCookie) *Cookie {
+	c := &Cookie{}
+	c.Name = p.Name
+	c.Value = p.Value
+	c.Domain = p.Domain
+	c.Path = p.Path
+	c.Expires = fromUnixSeconds(p.Expires)
+	c.HttpOnly = p.HTTPOnly
+	c.Secure = p.Secure
+	c.SameSite = parseSameSite(p.SameSite)
+	return c
+}
+
+func toPuppeteer(c *Cookie) puppeteerCookie {
+	p := puppeteerCookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		Secure:   c.Secure,
+		HTTPOnly: c.HttpOnly,
+		SameSite: sameSiteString(c.SameSite),
+	}
+	if !c.Expires.IsZero() {
+		exp := float64(c.Expires.Unix())
+		p.Expires = &exp
+	}
+	if c.Partitioned {
+		p.PartitionKey = c.PartitionKey
+	}
+	return p
+}
+
+func fromPuppeteer(p puppeteerCookie) *Cookie {
+	c := &Cookie{}
+	c.Name = p.Name
+	c.Value = p.Value
+	c.Domain = p.Domain
+	c.Path = p.Path
+	c.Secure = p.Secure
+	c.HttpOnly = p.HTTPOnly
+	c.SameSite = parseSameSite(p.SameSite)
+	if p.Expires != nil {
+		c.Expires = fromUnixSeconds(*p.Expires)
+	}
+	if len(p.PartitionKey) > 0 {
+		c.Partitioned = true
+		c.PartitionKey = p.PartitionKey
+	}
+	return c
+}
+
+func toSelenium(c *Cookie) seleniumCookie {
+	s := seleniumCookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		Secure:   c.Secure,
+		HTTPOnly: c.HttpOnly,
+		SameSite: sameSiteString(c.SameSite),
+	}
+	if !c.Expires.IsZero() {
+		exp := c.Expires.Unix()
+		s.Expiry = &exp
+	}
+	return s
+}
+
+func fromSelenium(s seleniumCookie) *Cookie {
+	c := &Cookie{}
+	c.Name = s.Name
+	c.Value = s.Value
+	c.Path = s.Path
+	c.Domain = s.Domain
+	c.Secure = s.Secure
+	c.HttpOnly = s.HTTPOnly
+	c.SameSite = parseSameSite(s.SameSite)
+	if s.Expiry != nil {
+		c.Expires = time.Unix(*s.Expiry, 0)
+	}
+	return c
+}
+
+// fromUnixSeconds converts fractional seconds since epoch; negative values mark session cookies.
+func fromUnixSeconds(sec float64) time.Time {
+	if sec < 0 {
+		return time.Time{}
+	}
+	s, frac := math.Modf(sec)
+	return time.Unix(int64(s), int64(frac*1e9))
+}
+
+func sameSiteString(s http.SameSite) string {
+	switch s {
+	case http.SameSiteStrictMode:
+		return `Strict`
+	case http.SameSiteLaxMode:
+		return `Lax`
+	case http.SameSiteNoneMode:
+		return `None`
+	default:
+		return ``
+	}
+}
+
+func parseSameSite(s string) http.SameSite {
+	switch s {
+	case `Strict`, `strict`:
+		return http.SameSiteStrictMode
+	case `Lax`, `lax`:
+		return http.SameSiteLaxMode
+	case `None`, `none`, `no_restriction`:
+		return http.SameSiteNoneMode
+	default:
+		return 0
+	}
+}
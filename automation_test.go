@@ -0,0 +1,52 @@
+package kooky
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImportFormats(t *testing.T) {
+	exp := time.Unix(4102444800, 0)
+	cookies := Cookies{
+		{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `sid`, Value: `abc`, Expires: exp, Secure: true, HttpOnly: true, SameSite: http.SameSiteStrictMode}},
+		{Cookie: http.Cookie{Domain: `example.com`, Path: `/`, Name: `session`, Value: `1`}},
+	}
+	seq := func(yield func(*Cookie, error) bool) {
+		for _, c := range cookies {
+			if !yield(c, nil) {
+				return
+			}
+		}
+	}
+
+	for _, format := range []Format{FormatPlaywright, FormatPuppeteer, FormatSelenium} {
+		var buf bytes.Buffer
+		if err := ExportCookiesFormat(context.Background(), &buf, format, seq); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if format == FormatPlaywright && !strings.Contains(buf.String(), `"expires": -1`) {
+			t.Errorf("%s: session cookie without expiry -1:\n%s", format, buf.String())
+		}
+		got, err := ImportCookies(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if len(got) != len(cookies) {
+			t.Fatalf("%s: got %d cookies, want %d", format, len(got), len(cookies))
+		}
+		for i, c := range got {
+			want := cookies[i]
+			if c.Name != want.Name || c.Value != want.Value || c.Domain != want.Domain || c.Path != want.Path ||
+				c.Secure != want.Secure || c.HttpOnly != want.HttpOnly || !c.Expires.Equal(want.Expires) {
+				t.Errorf("%s: got %+v, want %+v", format, c.Cookie, want.Cookie)
+			}
+		}
+		if got[0].SameSite != http.SameSiteStrictMode {
+			t.Errorf("%s: got SameSite %v, want Strict", format, got[0].SameSite)
+		}
+	}
+}
@@ -2,21 +2,45 @@
 package all
 
 import (
+	_ "github.com/browserutils/kooky/browser/arc"
 	_ "github.com/browserutils/kooky/browser/brave"
 	_ "github.com/browserutils/kooky/browser/browsh"
 	_ "github.com/browserutils/kooky/browser/chrome"
 	_ "github.com/browserutils/kooky/browser/chromium"
+	_ "github.com/browserutils/kooky/browser/coccoc"
 	_ "github.com/browserutils/kooky/browser/dillo"
 	_ "github.com/browserutils/kooky/browser/edge"
+	_ "github.com/browserutils/kooky/browser/electron"
 	_ "github.com/browserutils/kooky/browser/elinks"
 	_ "github.com/browserutils/kooky/browser/epiphany"
+	_ "github.com/browserutils/kooky/browser/falkon"
 	_ "github.com/browserutils/kooky/browser/firefox"
+	_ "github.com/browserutils/kooky/browser/floorp"
 	_ "github.com/browserutils/kooky/browser/ie"
+	_ "github.com/browserutils/kooky/browser/ios"
 	_ "github.com/browserutils/kooky/browser/konqueror"
+	_ "github.com/browserutils/kooky/browser/librewolf"
+	_ "github.com/browserutils/kooky/browser/luakit"
 	_ "github.com/browserutils/kooky/browser/lynx"
+	_ "github.com/browserutils/kooky/browser/midori"
+	_ "github.com/browserutils/kooky/browser/mullvad"
 	_ "github.com/browserutils/kooky/browser/netscape"
+	_ "github.com/browserutils/kooky/browser/nyxt"
 	_ "github.com/browserutils/kooky/browser/opera"
+	_ "github.com/browserutils/kooky/browser/palemoon"
+	_ "github.com/browserutils/kooky/browser/qtwebengine"
+	_ "github.com/browserutils/kooky/browser/qutebrowser"
 	_ "github.com/browserutils/kooky/browser/safari"
+	_ "github.com/browserutils/kooky/browser/seamonkey"
+	_ "github.com/browserutils/kooky/browser/surf"
+	_ "github.com/browserutils/kooky/browser/thorium"
+	_ "github.com/browserutils/kooky/browser/tor"
 	_ "github.com/browserutils/kooky/browser/uzbl"
+	_ "github.com/browserutils/kooky/browser/vivaldi"
 	_ "github.com/browserutils/kooky/browser/w3m"
+	_ "github.com/browserutils/kooky/browser/waterfox"
+	_ "github.com/browserutils/kooky/browser/webview"
+	_ "github.com/browserutils/kooky/browser/whale"
+	_ "github.com/browserutils/kooky/browser/yandex"
+	_ "github.com/browserutils/kooky/browser/zen"
 )
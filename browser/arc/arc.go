@@ -0,0 +1,30 @@
+// Package arc reads the cookies of Arc by The Browser Company (macOS, Windows), a Chromium derivative.
+package arc
+
+import (
+	"context"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+	"github.com/browserutils/kooky/internal/chrome/find"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+var derivative = find.LookupDerivative(`arc`)
+
+func ReadCookies(ctx context.Context, filename string, filters ...kooky.Filter) ([]*kooky.Cookie, error) {
+	return cookies.SingleRead(cookieStore, filename, filters...).ReadAllCookies(ctx)
+}
+
+func TraverseCookies(filename string, filters ...kooky.Filter) kooky.CookieSeq {
+	return cookies.SingleRead(cookieStore, filename, filters...)
+}
+
+// CookieStore has to be closed with CookieStore.Close() after use.
+func CookieStore(filename string, filters ...kooky.Filter) (kooky.CookieStore, error) {
+	return cookieStore(filename, filters...)
+}
+
+func cookieStore(filename string, filters ...kooky.Filter) (*cookies.CookieJar, error) {
+	return chrome.DerivativeCookieStore(derivative, filename, filters...)
+}
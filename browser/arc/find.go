@@ -0,0 +1,10 @@
+package arc
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+)
+
+func init() {
+	kooky.RegisterFinder(`arc`, &chrome.DerivativeFinder{Derivative: derivative})
+}
@@ -0,0 +1,40 @@
+// Package bidi reads cookies from a running browser via WebDriver BiDi,
+// e.g. Firefox started with "--remote-debugging-port=9222".
+//
+// Unlike the cookie database and session store files, this covers the current state
+// including private windows.
+// Containers (BiDi user contexts) are stored in Cookie.Container by their name
+// if the Firefox profile is accessible, by their user context ID otherwise.
+// Partitioned cookies are read for the top-level sites of open tabs and of the unpartitioned cookies.
+package bidi
+
+import (
+	"context"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/bidi"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+func ReadCookies(ctx context.Context, endpoint string, filters ...kooky.Filter) ([]*kooky.Cookie, error) {
+	return cookies.SingleRead(cookieStore, endpoint, filters...).ReadAllCookies(ctx)
+}
+
+func TraverseCookies(endpoint string, filters ...kooky.Filter) kooky.CookieSeq {
+	return cookies.SingleRead(cookieStore, endpoint, filters...)
+}
+
+// CookieStore has to be closed with CookieStore.Close() after use.
+//
+// The cookie store implements kooky.CookieWriter.
+func CookieStore(endpoint string, filters ...kooky.Filter) (kooky.CookieStore, error) {
+	return cookieStore(endpoint, filters...)
+}
+
+func cookieStore(endpoint string, filters ...kooky.Filter) (*cookies.CookieJar, error) {
+	s := &bidi.CookieStore{}
+	s.FileNameStr = endpoint
+	s.BrowserStr = `bidi`
+
+	return cookies.NewCookieJar(s, filters...), nil
+}
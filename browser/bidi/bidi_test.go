@@ -0,0 +1,189 @@
+package bidi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/browserutils/kooky"
+)
+
+// stubBrowser answers WebDriver BiDi commands like Firefox.
+type stubBrowser struct {
+	profile string
+	mu      sync.Mutex
+	set     []map[string]any
+	deleted []map[string]any
+	ended   bool
+}
+
+func (b *stubBrowser) serve(ws *websocket.Conn) {
+	for {
+		var cmd struct {
+			ID     int64          `json:"id"`
+			Method string         `json:"method"`
+			Params map[string]any `json:"params"`
+		}
+		if err := websocket.JSON.Receive(ws, &cmd); err != nil {
+			return
+		}
+		// events have to be skipped by the client
+		_ = websocket.JSON.Send(ws, map[string]any{`type`: `event`, `method`: `log.entryAdded`, `params`: map[string]any{}})
+
+		resp := map[string]any{`type`: `success`, `id`: cmd.ID}
+		b.mu.Lock()
+		switch cmd.Method {
+		case `session.new`:
+			resp[`result`] = map[string]any{`sessionId`: `s1`, `capabilities`: map[string]any{`moz:profile`: b.profile}}
+		case `session.end`:
+			b.ended = true
+			resp[`result`] = map[string]any{}
+		case `browser.getUserContexts`:
+			resp[`result`] = json.RawMessage(`{"userContexts":[{"userContext":"default"},{"userContext":"c0ffee"}]}`)
+		case `browsingContext.getTree`:
+			resp[`result`] = json.RawMessage(`{"contexts":[
+				{"context":"t1","url":"https://www.example.net/page","userContext":"default","children":null},
+				{"context":"t2","url":"about:blank","userContext":"c0ffee","children":null}
+			]}`)
+		case `storage.getCookies`:
+			partition, _ := cmd.Params[`partition`].(map[string]any)
+			switch sourceOrigin, _ := partition[`sourceOrigin`].(string); {
+			case sourceOrigin == `https://example.net` && partition[`userContext`] == `default`:
+				resp[`result`] = json.RawMessage(`{"cookies":[
+					{"name":"chips","value":{"type":"string","value":"1"},"domain":"widget.example.org","path":"/",
+					 "size":6,"httpOnly":false,"secure":true,"sameSite":"none","expiry":4102444800}
+				],"partitionKey":{"userContext":"default","sourceOrigin":"https://example.net"}}`)
+			case len(sourceOrigin) > 0:
+				resp[`result`] = json.RawMessage(`{"cookies":[],"partitionKey":{}}`)
+			case partition[`userContext`] == `c0ffee`:
+				resp[`result`] = json.RawMessage(`{"cookies":[
+					{"name":"work","value":{"type":"base64","value":"dmFsdWU="},"domain":"example.com","path":"/",
+					 "size":9,"httpOnly":false,"secure":true,"sameSite":"none"}
+				],"partitionKey":{"userContext":"c0ffee"}}`)
+			default:
+				resp[`result`] = json.RawMessage(`{"cookies":[
+					{"name":"sid","value":{"type":"string","value":"abc"},"domain":".example.com","path":"/",
+					 "size":6,"httpOnly":true,"secure":true,"sameSite":"lax","expiry":4102444800}
+				],"partitionKey":{"userContext":"default"}}`)
+			}
+		case `storage.setCookie`:
+			b.set = append(b.set, cmd.Params)
+			resp[`result`] = map[string]any{`partitionKey`: map[string]any{}}
+		case `storage.deleteCookies`:
+			b.deleted = append(b.deleted, cmd.Params)
+			resp[`result`] = map[string]any{`partitionKey`: map[string]any{}}
+		default:
+			resp = map[string]any{`type`: `error`, `id`: cmd.ID, `error`: `unknown command`, `message`: cmd.Method}
+		}
+		b.mu.Unlock()
+		if err := websocket.JSON.Send(ws, resp); err != nil {
+			return
+		}
+	}
+}
+
+func newStubServer(t *testing.T, b *stubBrowser) string {
+	srv := httptest.NewServer(websocket.Handler(b.serve))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, `http://`)
+}
+
+// newProfile returns a profile directory with a public and an internal container.
+func newProfile(t *testing.T) string {
+	dir := t.TempDir()
+	containers := `{"version":5,"lastUserContextId":5,"identities":[
+		{"userContextId":1,"public":true,"icon":"fingerprint","color":"blue","l10nID":"user-context-personal","accessKey":"userContextPersonal.accesskey"},
+		{"userContextId":5,"public":false,"icon":"","color":"","name":"userContextIdInternal.thumbnail","accessKey":""}
+	]}`
+	if err := os.WriteFile(filepath.Join(dir, `containers.json`), []byte(containers), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestReadCookies(t *testing.T) {
+	b := &stubBrowser{profile: newProfile(t)}
+	endpoint := newStubServer(t, b)
+
+	cookies, err := ReadCookies(context.Background(), endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 3 {
+		t.Fatalf("got %d cookies, want 3", len(cookies))
+	}
+	c := cookies[0]
+	if c.Name != `sid` || c.Value != `abc` || len(c.Container) > 0 || c.SameSite != http.SameSiteLaxMode || c.Expires.Unix() != 4102444800 || c.Partitioned {
+		t.Errorf("wrong cookie %+v", c.Cookie)
+	}
+	c = cookies[1]
+	if c.Name != `chips` || !c.Partitioned || c.PartitionKey != `https://example.net` || len(c.Container) > 0 {
+		t.Errorf("wrong partitioned cookie %+v (partition key %q)", c.Cookie, c.PartitionKey)
+	}
+	c = cookies[2]
+	if c.Name != `work` || c.Value != `value` || c.Container != `Personal` || !c.Expires.IsZero() {
+		t.Errorf("wrong container cookie %+v (container %q)", c.Cookie, c.Container)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.ended {
+		t.Error(`session not ended`)
+	}
+}
+
+func TestReadCookiesWithoutProfile(t *testing.T) {
+	endpoint := newStubServer(t, &stubBrowser{})
+
+	cookies, err := ReadCookies(context.Background(), endpoint, kooky.Name(`work`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 1 || cookies[0].Container != `c0ffee` {
+		t.Fatalf("got %v, want the container cookie with the user context ID", cookies)
+	}
+}
+
+func TestWriteCookies(t *testing.T) {
+	b := &stubBrowser{profile: newProfile(t)}
+	endpoint := newStubServer(t, b)
+
+	st, err := CookieStore(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	c := &kooky.Cookie{Container: `Personal`, PartitionKey: `https://example.org`}
+	c.Name, c.Value, c.Domain, c.Path, c.Partitioned = `chips`, `1`, `.example.com`, `/`, true
+	gone := &kooky.Cookie{}
+	gone.Name, gone.Domain, gone.MaxAge = `old`, `example.com`, -1
+	if err := st.(kooky.CookieWriter).WriteCookies(c, gone); err != nil {
+		t.Fatal(err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.set) != 1 || len(b.deleted) != 1 {
+		t.Fatalf("got %d set and %d deleted cookies, want 1 each", len(b.set), len(b.deleted))
+	}
+	// the leading dot makes a domain cookie
+	cookie, _ := b.set[0][`cookie`].(map[string]any)
+	if cookie[`domain`] != `.example.com` || cookie[`name`] != `chips` {
+		t.Errorf("wrong cookie %v", cookie)
+	}
+	partition, _ := b.set[0][`partition`].(map[string]any)
+	if partition[`userContext`] != `c0ffee` || partition[`sourceOrigin`] != `https://example.org` {
+		t.Errorf("wrong partition %v", partition)
+	}
+	filter, _ := b.deleted[0][`filter`].(map[string]any)
+	if filter[`domain`] != `example.com` {
+		t.Errorf("wrong deletion filter %v", filter)
+	}
+}
@@ -9,7 +9,11 @@ import (
 
 type braveFinder struct{}
 
-var _ kooky.CookieStoreFinder = (*braveFinder)(nil)
+var (
+	_ kooky.CookieStoreFinder = (*braveFinder)(nil)
+	_ kooky.ProfileFinder     = (*braveFinder)(nil)
+	_ kooky.RootFinder        = (*braveFinder)(nil)
+)
 
 func init() {
 	kooky.RegisterFinder(`brave`, &braveFinder{})
@@ -27,20 +31,30 @@ func (f *braveFinder) FindCookieStores() kooky.CookieStoreSeq {
 			if file == nil {
 				continue
 			}
-			st := &cookies.CookieJar{
-				CookieStore: &chrome.CookieStore{
-					DefaultCookieStore: cookies.DefaultCookieStore{
-						BrowserStr:           file.Browser,
-						ProfileStr:           file.Profile,
-						OSStr:                file.OS,
-						IsDefaultProfileBool: file.IsDefaultProfile,
-						FileNameStr:          file.Path,
-					},
+			cookieStore := &chrome.CookieStore{
+				DefaultCookieStore: cookies.DefaultCookieStore{
+					BrowserStr:           file.Browser,
+					ProfileStr:           file.Profile,
+					OSStr:                file.OS,
+					IsDefaultProfileBool: file.IsDefaultProfile,
+					FileNameStr:          file.Path,
 				},
 			}
+			// Flatpak installations encrypt with a secret from the xdg-desktop-portal
+			cookieStore.SetPortalAppID(file.PortalAppID)
+			st := &cookies.CookieJar{CookieStore: cookieStore}
 			if !yield(st, nil) {
 				return
 			}
 		}
 	}
 }
+
+func (f *braveFinder) FindProfiles() kooky.ProfileSeq {
+	return chrome.KookyProfiles(find.FindBraveProfiles())
+}
+
+// FindCookieStoresIn() finds the cookie stores in Android app data extracted to root.
+func (f *braveFinder) FindCookieStoresIn(root string) kooky.CookieStoreSeq {
+	return chrome.FindAndroidCookieStores(root, `brave`)
+}
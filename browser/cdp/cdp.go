@@ -0,0 +1,45 @@
+// Package cdp reads cookies from a running Chromium based browser
+// via the Chrome DevTools Protocol ("--remote-debugging-port").
+//
+// This avoids decrypting the cookie database which isn't possible for
+// v20 App-Bound Encryption on Windows.
+//
+// Start the browser like this:
+//
+//	chrome --remote-debugging-port=9222 --remote-allow-origins=http://127.0.0.1:9222
+//
+// and use "http://127.0.0.1:9222" as endpoint.
+// Cookie priority, sameParty and sourceScheme are stored as "Priority=High", "SameParty"
+// and "SourceScheme=Secure" in http.Cookie.Unparsed.
+package cdp
+
+import (
+	"context"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cdp"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+func ReadCookies(ctx context.Context, endpoint string, filters ...kooky.Filter) ([]*kooky.Cookie, error) {
+	return cookies.SingleRead(cookieStore, endpoint, filters...).ReadAllCookies(ctx)
+}
+
+func TraverseCookies(endpoint string, filters ...kooky.Filter) kooky.CookieSeq {
+	return cookies.SingleRead(cookieStore, endpoint, filters...)
+}
+
+// CookieStore has to be closed with CookieStore.Close() after use.
+//
+// The cookie store implements kooky.CookieWriter.
+func CookieStore(endpoint string, filters ...kooky.Filter) (kooky.CookieStore, error) {
+	return cookieStore(endpoint, filters...)
+}
+
+func cookieStore(endpoint string, filters ...kooky.Filter) (*cookies.CookieJar, error) {
+	s := &cdp.CookieStore{}
+	s.FileNameStr = endpoint
+	s.BrowserStr = `cdp`
+
+	return cookies.NewCookieJar(s, filters...), nil
+}
@@ -0,0 +1,260 @@
+package cdp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/browserutils/kooky"
+)
+
+// stubBrowser answers DevTools calls like the browser target of Chrome.
+// Only the browser wide domains are available there, Network methods fail.
+type stubBrowser struct {
+	mu      sync.Mutex
+	product string // Browser.getVersion, Chrome 130 if empty
+	set     []map[string]any
+	deleted []map[string]any
+}
+
+// legacy reports whether the stub uses the partition key strings of Chrome versions before 128.
+func (b *stubBrowser) legacy() bool { return strings.HasPrefix(b.product, `Chrome/127.`) }
+
+// validCookieParams reports an error message if the cookies aren't valid Storage.setCookies parameters.
+func validCookieParams(params map[string]any, legacy bool) string {
+	for k := range params {
+		if k != `cookies` && k != `browserContextId` {
+			return `unknown parameter ` + k
+		}
+	}
+	cookies, ok := params[`cookies`].([]any)
+	if !ok {
+		return `cookies: array expected`
+	}
+	for _, c := range cookies {
+		c, ok := c.(map[string]any)
+		if !ok {
+			return `cookie: object expected`
+		}
+		if _, ok := c[`name`].(string); !ok {
+			return `cookie name: string expected`
+		}
+		_, hasURL := c[`url`]
+		_, hasDomain := c[`domain`]
+		if !hasURL && !hasDomain {
+			return `At least one of the url or domain needs to be specified`
+		}
+		pk, ok := c[`partitionKey`]
+		if !ok {
+			continue
+		}
+		if legacy {
+			if _, ok := pk.(string); !ok {
+				return `partitionKey: string expected`
+			}
+		} else if pk, ok := pk.(map[string]any); !ok || pk[`topLevelSite`] == nil || pk[`hasCrossSiteAncestor`] == nil {
+			return `partitionKey: object with topLevelSite and hasCrossSiteAncestor expected`
+		}
+	}
+	return ``
+}
+
+func (b *stubBrowser) serve(ws *websocket.Conn) {
+	for {
+		var req struct {
+			ID     int64          `json:"id"`
+			Method string         `json:"method"`
+			Params map[string]any `json:"params"`
+		}
+		if err := websocket.JSON.Receive(ws, &req); err != nil {
+			return
+		}
+		// events have to be skipped by the client
+		_ = websocket.JSON.Send(ws, map[string]any{`method`: `Target.targetCreated`, `params`: map[string]any{}})
+
+		resp := map[string]any{`id`: req.ID}
+		b.mu.Lock()
+		switch req.Method {
+		case `Storage.getCookies`:
+			if len(req.Params) > 0 {
+				if _, ok := req.Params[`browserContextId`]; !ok || len(req.Params) > 1 {
+					resp[`error`] = map[string]any{`code`: -32602, `message`: `Invalid parameters`}
+					break
+				}
+			}
+			partitionKey := `{"topLevelSite":"https://example.org","hasCrossSiteAncestor":true}`
+			if b.legacy() {
+				partitionKey = `"https://example.org"`
+			}
+			resp[`result`] = json.RawMessage(`{"cookies":[
+				{"name":"sid","value":"abc","domain":".example.com","path":"/","expires":4102444800.5,"size":6,
+				 "httpOnly":true,"secure":true,"session":false,"sameSite":"Lax","priority":"High",
+				 "sameParty":false,"sourceScheme":"Secure","sourcePort":443},
+				{"name":"chips","value":"1","domain":"example.com","path":"/","expires":-1,"size":6,
+				 "httpOnly":false,"secure":true,"session":true,"sameSite":"None","priority":"Medium",
+				 "sourceScheme":"Secure","partitionKey":` + partitionKey + `}
+			]}`)
+		case `Browser.getVersion`:
+			product := b.product
+			if len(product) == 0 {
+				product = `Chrome/130.0.6723.58`
+			}
+			resp[`result`] = map[string]any{`protocolVersion`: `1.3`, `product`: product}
+		case `Storage.setCookies`:
+			if msg := validCookieParams(req.Params, b.legacy()); len(msg) > 0 {
+				resp[`error`] = map[string]any{`code`: -32602, `message`: msg}
+				break
+			}
+			now := float64(time.Now().Unix())
+			for _, c := range req.Params[`cookies`].([]any) {
+				c := c.(map[string]any)
+				// setting an expired cookie deletes it
+				if exp, ok := c[`expires`].(float64); ok && exp > 0 && exp < now {
+					b.deleted = append(b.deleted, c)
+				} else {
+					b.set = append(b.set, c)
+				}
+			}
+			resp[`result`] = map[string]any{}
+		default:
+			resp[`error`] = map[string]any{`code`: -32601, `message`: `'` + req.Method + `' wasn't found`}
+		}
+		b.mu.Unlock()
+		if err := websocket.JSON.Send(ws, resp); err != nil {
+			return
+		}
+	}
+}
+
+func newStubServer(t *testing.T, b *stubBrowser) *httptest.Server {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.Handle(`/devtools/browser/stub`, websocket.Handler(b.serve))
+	mux.HandleFunc(`/json/version`, func(w http.ResponseWriter, r *http.Request) {
+		wsURL := `ws://` + strings.TrimPrefix(srv.URL, `http://`) + `/devtools/browser/stub`
+		json.NewEncoder(w).Encode(map[string]string{`Browser`: `Chrome/130.0`, `webSocketDebuggerUrl`: wsURL})
+	})
+	return srv
+}
+
+func TestReadCookies(t *testing.T) {
+	for _, product := range []string{`Chrome/130.0.6723.58`, `Chrome/127.0.6533.88`} {
+		b := &stubBrowser{product: product}
+		srv := newStubServer(t, b)
+
+		cookies, err := ReadCookies(context.Background(), srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cookies) != 2 {
+			t.Fatalf("%s: got %d cookies, want 2", product, len(cookies))
+		}
+		c := cookies[0]
+		if c.Name != `sid` || c.Domain != `.example.com` || !c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteLaxMode {
+			t.Errorf("%s: wrong cookie %+v", product, c.Cookie)
+		}
+		if want := time.Unix(4102444800, 5e8); !c.Expires.Equal(want) {
+			t.Errorf("%s: got expiry %v, want %v", product, c.Expires, want)
+		}
+		if !slices.Contains(c.Unparsed, `Priority=High`) || !slices.Contains(c.Unparsed, `SourceScheme=Secure`) {
+			t.Errorf("%s: got unparsed attributes %q", product, c.Unparsed)
+		}
+		c = cookies[1]
+		if !c.Expires.IsZero() || !c.Partitioned || c.PartitionKey != `https://example.org` || c.SameSite != http.SameSiteNoneMode {
+			t.Errorf("%s: wrong partitioned session cookie %+v (partition key %q)", product, c.Cookie, c.PartitionKey)
+		}
+		// only in the partition key objects of Chrome 128+
+		if got := slices.Contains(c.Unparsed, `HasCrossSiteAncestor=true`); got == b.legacy() {
+			t.Errorf("%s: got unparsed attributes %q", product, c.Unparsed)
+		}
+	}
+}
+
+// partition keys are written in the form of the browser version, hasCrossSiteAncestor is kept
+func TestPartitionKeyRoundTrip(t *testing.T) {
+	tests := []struct {
+		product string
+		want    any
+	}{
+		{`Chrome/130.0.6723.58`, map[string]any{`topLevelSite`: `https://example.org`, `hasCrossSiteAncestor`: true}},
+		{`Chrome/127.0.6533.88`, `https://example.org`},
+	}
+	for _, tt := range tests {
+		b := &stubBrowser{product: tt.product}
+		srv := newStubServer(t, b)
+		st, err := CookieStore(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cookies, err := st.TraverseCookies(kooky.Name(`chips`)).ReadAllCookies(context.Background())
+		if err != nil || len(cookies) != 1 {
+			t.Fatalf("%s: got %d cookies, error %v", tt.product, len(cookies), err)
+		}
+		if err := st.(kooky.CookieWriter).WriteCookies(cookies...); err != nil {
+			t.Fatalf("%s: %v", tt.product, err)
+		}
+		st.Close()
+
+		b.mu.Lock()
+		if len(b.set) != 1 {
+			t.Fatalf("%s: got %d set cookies, want 1", tt.product, len(b.set))
+		}
+		if got := b.set[0][`partitionKey`]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got partition key %#v, want %#v", tt.product, got, tt.want)
+		}
+		b.mu.Unlock()
+	}
+}
+
+func TestWriteCookies(t *testing.T) {
+	b := &stubBrowser{}
+	srv := newStubServer(t, b)
+
+	st, err := CookieStore(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	w, ok := st.(kooky.CookieWriter)
+	if !ok {
+		t.Fatal(`cookie store is not a kooky.CookieWriter`)
+	}
+	c := &kooky.Cookie{PartitionKey: `https://example.org`}
+	c.Name, c.Value, c.Domain, c.Secure, c.Partitioned = `chips`, `1`, `example.com`, true, true
+	c.Unparsed = []string{`Priority=Low`}
+	gone := &kooky.Cookie{}
+	gone.Name, gone.Domain, gone.Path, gone.MaxAge = `old`, `.example.com`, `/`, -1
+	if err := w.WriteCookies(c, gone); err != nil {
+		t.Fatal(err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.set) != 1 || len(b.deleted) != 1 {
+		t.Fatalf("got %d set and %d deleted cookies, want 1 each", len(b.set), len(b.deleted))
+	}
+	set := b.set[0]
+	if set[`name`] != `chips` || set[`path`] != `/` || set[`priority`] != `Low` {
+		t.Errorf("wrong cookie parameters %v", set)
+	}
+	// host-only cookies are set by URL, a domain would make them domain cookies
+	if _, ok := set[`domain`]; ok || set[`url`] != `https://example.com/` {
+		t.Errorf("host-only cookie set with domain %v and url %v", set[`domain`], set[`url`])
+	}
+	if pk, _ := set[`partitionKey`].(map[string]any); pk[`topLevelSite`] != `https://example.org` {
+		t.Errorf("wrong partition key %v", set[`partitionKey`])
+	}
+	if b.deleted[0][`name`] != `old` || b.deleted[0][`domain`] != `.example.com` {
+		t.Errorf("wrong deletion parameters %v", b.deleted[0])
+	}
+}
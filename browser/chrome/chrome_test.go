@@ -2,12 +2,21 @@ package chrome
 
 import (
 	"context"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/browserutils/kooky"
 	"github.com/browserutils/kooky/internal/chrome"
+	"github.com/browserutils/kooky/internal/chrome/find"
 	"github.com/browserutils/kooky/internal/testutils"
+	_ "github.com/browserutils/kooky/sqlitewrite"
 )
 
 func TestReadCookies(t *testing.T) {
@@ -54,3 +63,158 @@ func TestReadCookies(t *testing.T) {
 		t.Errorf("Want cookie.Creation=%v; got %v", wantCreation, cookie.Creation)
 	}
 }
+
+func TestFindFlatpakCookieStores(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`Flatpak is Linux only`)
+	}
+	home := t.TempDir()
+	t.Setenv(`HOME`, home)
+	t.Setenv(`XDG_CONFIG_HOME`, filepath.Join(home, `.config`))
+	root := filepath.Join(home, `.var`, `app`, `com.google.Chrome`, `config`, `google-chrome`)
+	if err := os.MkdirAll(filepath.Join(root, `Default`), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	localState := `{"profile":{"info_cache":{"Default":{"name":"Person 1","is_using_default_name":true}}}}`
+	if err := os.WriteFile(filepath.Join(root, `Local State`), []byte(localState), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for st, err := range kooky.Finder(`chrome`).FindCookieStores() {
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // roots outside of the Flatpak
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, st.FilePath())
+		st.Close()
+	}
+	want := filepath.Join(root, `Default`, `Network`, `Cookies`)
+	if !slices.Contains(paths, want) {
+		t.Errorf(`got cookie store files %q; want %q`, paths, want)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	root := t.TempDir()
+	localState := `{"profile":{"last_used":"Profile 1","info_cache":{` +
+		`"Default":{"name":"Person 1","is_using_default_name":true,"gaia_name":"Jane Doe"},` +
+		`"Profile 1":{"name":"Work","is_using_default_name":false,"gaia_name":"Jane Doe"}}}}`
+	if err := os.WriteFile(filepath.Join(root, `Local State`), []byte(localState), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	roots := func(yield func(string, error) bool) { yield(root, nil) }
+
+	type profile struct {
+		displayName string
+		isDefault   bool
+	}
+	got := map[string]profile{}
+	for p, err := range chrome.KookyProfiles(find.FindProfiles(roots, `chrome`)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[p.Name] = profile{p.DisplayName, p.IsDefault}
+	}
+	want := map[string]profile{
+		`Person 1`: {displayName: `Jane Doe`},
+		`Work`:     {displayName: `Work`, isDefault: true},
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf(`got profiles %v; want %v`, got, want)
+	}
+}
+
+func TestFindCookieStoresInAndroidAppData(t *testing.T) {
+	// copy of the device root with a secondary user
+	root := t.TempDir()
+	testutils.CopyTestDataFile(t, `qtwebengine-cookies.sqlite`, filepath.Join(root, `data`, `data`, `com.android.chrome`, `app_chrome`, `Default`, `Cookies`))
+	testutils.CopyTestDataFile(t, `qtwebengine-cookies.sqlite`, filepath.Join(root, `data`, `user`, `10`, `com.chrome.beta`, `app_chrome`, `Default`, `Cookies`))
+
+	ctx := context.Background()
+	got := map[string]bool{}
+	for st, err := range kooky.TraverseCookieStores(ctx, kooky.OnlyBrowsers(`chrome`), kooky.AlternateRoot(root)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		cookies, err := st.TraverseCookies().ReadAllCookies(ctx)
+		st.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cookies) != 1 || cookies[0].Value != `plain-value` {
+			t.Errorf(`%s: got cookies %v; want the plain value`, st.FilePath(), cookies)
+		}
+		got[st.Profile()] = st.IsDefaultProfile()
+	}
+	want := map[string]bool{`com.android.chrome`: true, `com.chrome.beta (user 10)`: false}
+	if !maps.Equal(got, want) {
+		t.Errorf(`got profiles %v; want %v`, got, want)
+	}
+}
+
+func TestWriteCookies(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath("chrome-macos-cookie-db.sqlite")
+	if err != nil {
+		t.Fatalf("Failed to load test data file")
+	}
+	b, err := os.ReadFile(testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), `Cookies`)
+	if err := os.WriteFile(filename, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	newStore := func() *chrome.CookieStore {
+		s := &chrome.CookieStore{}
+		s.FileNameStr = filename
+		s.SetKeyringPassword([]byte("ChromeSafeStoragePasswrd"))
+		return s
+	}
+
+	update := &kooky.Cookie{}
+	update.Domain = `news.ycombinator.com`
+	update.Path = `/`
+	update.Name = `user`
+	update.Value = `updated`
+	update.Expires = time.Now().Add(time.Hour).Truncate(time.Second)
+	added := &kooky.Cookie{}
+	added.Domain = `.example.com`
+	added.Path = `/`
+	added.Name = `added`
+	added.Value = `secret value`
+	added.Secure = true
+
+	s := newStore()
+	if err := s.WriteCookies(update, added); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s = newStore()
+	defer s.Close()
+	ctx := context.Background()
+	cookies, err := s.TraverseCookies().ReadAllCookies(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	for _, c := range cookies {
+		if c.Domain == `news.ycombinator.com` && c.Name == `user` {
+			n++
+			if c.Value != `updated` || !c.Expires.Equal(update.Expires) || c.Secure {
+				t.Errorf("got updated cookie %q expiring %v", c.Value, c.Expires)
+			}
+		}
+	}
+	if n != 1 {
+		t.Errorf("got %d cookies with the updated name, expected 1", n)
+	}
+	got := kooky.FilterCookies(ctx, cookies, kooky.Name(`added`)).Collect(ctx)
+	if len(got) != 1 || got[0].Value != added.Value || !got[0].Secure {
+		t.Errorf("added cookie not read back: %v", got)
+	}
+}
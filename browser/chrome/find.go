@@ -9,7 +9,11 @@ import (
 
 type chromeFinder struct{}
 
-var _ kooky.CookieStoreFinder = (*chromeFinder)(nil)
+var (
+	_ kooky.CookieStoreFinder = (*chromeFinder)(nil)
+	_ kooky.ProfileFinder     = (*chromeFinder)(nil)
+	_ kooky.RootFinder        = (*chromeFinder)(nil)
+)
 
 func init() {
 	kooky.RegisterFinder(`chrome`, &chromeFinder{})
@@ -24,20 +28,30 @@ func (f *chromeFinder) FindCookieStores() kooky.CookieStoreSeq {
 				}
 				continue
 			}
-			st := &cookies.CookieJar{
-				CookieStore: &chrome.CookieStore{
-					DefaultCookieStore: cookies.DefaultCookieStore{
-						BrowserStr:           file.Browser,
-						ProfileStr:           file.Profile,
-						OSStr:                file.OS,
-						IsDefaultProfileBool: file.IsDefaultProfile,
-						FileNameStr:          file.Path,
-					},
+			cookieStore := &chrome.CookieStore{
+				DefaultCookieStore: cookies.DefaultCookieStore{
+					BrowserStr:           file.Browser,
+					ProfileStr:           file.Profile,
+					OSStr:                file.OS,
+					IsDefaultProfileBool: file.IsDefaultProfile,
+					FileNameStr:          file.Path,
 				},
 			}
+			// Flatpak installations encrypt with a secret from the xdg-desktop-portal
+			cookieStore.SetPortalAppID(file.PortalAppID)
+			st := &cookies.CookieJar{CookieStore: cookieStore}
 			if !yield(st, nil) {
 				return
 			}
 		}
 	}
 }
+
+func (f *chromeFinder) FindProfiles() kooky.ProfileSeq {
+	return chrome.KookyProfiles(find.FindChromeProfiles())
+}
+
+// FindCookieStoresIn() finds the cookie stores in Android app data extracted to root.
+func (f *chromeFinder) FindCookieStoresIn(root string) kooky.CookieStoreSeq {
+	return chrome.FindAndroidCookieStores(root, `chrome`)
+}
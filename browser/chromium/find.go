@@ -9,7 +9,11 @@ import (
 
 type chromiumFinder struct{}
 
-var _ kooky.CookieStoreFinder = (*chromiumFinder)(nil)
+var (
+	_ kooky.CookieStoreFinder = (*chromiumFinder)(nil)
+	_ kooky.ProfileFinder     = (*chromiumFinder)(nil)
+	_ kooky.RootFinder        = (*chromiumFinder)(nil)
+)
 
 func init() {
 	kooky.RegisterFinder(`chromium`, &chromiumFinder{})
@@ -24,20 +28,30 @@ func (f *chromiumFinder) FindCookieStores() kooky.CookieStoreSeq {
 				}
 				continue
 			}
-			st := &cookies.CookieJar{
-				CookieStore: &chrome.CookieStore{
-					DefaultCookieStore: cookies.DefaultCookieStore{
-						BrowserStr:           file.Browser,
-						ProfileStr:           file.Profile,
-						OSStr:                file.OS,
-						IsDefaultProfileBool: file.IsDefaultProfile,
-						FileNameStr:          file.Path,
-					},
+			cookieStore := &chrome.CookieStore{
+				DefaultCookieStore: cookies.DefaultCookieStore{
+					BrowserStr:           file.Browser,
+					ProfileStr:           file.Profile,
+					OSStr:                file.OS,
+					IsDefaultProfileBool: file.IsDefaultProfile,
+					FileNameStr:          file.Path,
 				},
 			}
+			// Flatpak installations encrypt with a secret from the xdg-desktop-portal
+			cookieStore.SetPortalAppID(file.PortalAppID)
+			st := &cookies.CookieJar{CookieStore: cookieStore}
 			if !yield(st, nil) {
 				return
 			}
 		}
 	}
 }
+
+func (f *chromiumFinder) FindProfiles() kooky.ProfileSeq {
+	return chrome.KookyProfiles(find.FindChromiumProfiles())
+}
+
+// FindCookieStoresIn() finds the cookie stores in Android app data extracted to root.
+func (f *chromiumFinder) FindCookieStoresIn(root string) kooky.CookieStoreSeq {
+	return chrome.FindAndroidCookieStores(root, `chromium`)
+}
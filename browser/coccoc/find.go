@@ -0,0 +1,10 @@
+package coccoc
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+)
+
+func init() {
+	kooky.RegisterFinder(`coccoc`, &chrome.DerivativeFinder{Derivative: derivative})
+}
@@ -11,7 +11,10 @@ import (
 
 type edgeFinder struct{}
 
-var _ kooky.CookieStoreFinder = (*edgeFinder)(nil)
+var (
+	_ kooky.CookieStoreFinder = (*edgeFinder)(nil)
+	_ kooky.ProfileFinder     = (*edgeFinder)(nil)
+)
 
 func init() {
 	kooky.RegisterFinder(`edge`, &edgeFinder{})
@@ -45,6 +48,8 @@ func (f *edgeFinder) FindCookieStores() kooky.CookieStoreSeq {
 			default:
 				cookieStore.SetSafeStorage(`Chromium`, ``, ``)
 			}
+			// Flatpak installations encrypt with a secret from the xdg-desktop-portal
+			cookieStore.SetPortalAppID(file.PortalAppID)
 			if !yield(&cookies.CookieJar{CookieStore: cookieStore}, nil) {
 				return
 			}
@@ -68,3 +73,7 @@ func (f *edgeFinder) FindCookieStores() kooky.CookieStoreSeq {
 }
 
 var edgeOldCookieStores kooky.CookieStoreSeq
+
+func (f *edgeFinder) FindProfiles() kooky.ProfileSeq {
+	return chrome.KookyProfiles(chromefind.FindProfiles(edgeChromiumRoots, `edge`))
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/browserutils/kooky/internal/sandbox"
 	"github.com/browserutils/kooky/internal/windowsx"
 )
 
@@ -18,6 +19,11 @@ func edgeChromiumRoots(yield func(string, error) bool) {
 	if !yield(filepath.Join(cfgDir, `microsoft-edge`), nil) {
 		return
 	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if !yield(sandbox.FlatpakConfigDir(home, `com.microsoft.Edge`, `microsoft-edge`), nil) {
+			return
+		}
+	}
 	// on WSL Linux add Windows paths
 	if !windowsx.IsWSL() {
 		return
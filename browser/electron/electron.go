@@ -0,0 +1,130 @@
+// Package electron reads the cookies of Electron and CEF based desktop apps
+// like Slack, Discord or Visual Studio Code.
+//
+// These apps store their cookies like Chromium, but directly in the user data directory
+// without the profiles listed in a "Local State" file.
+// The cookie stores are found for the apps in a registry which can be extended with RegisterApp().
+// The browser name of the cookie stores is "electron", the profile name is the app name.
+package electron
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+// App describes where an Electron app stores its cookies and how they are encrypted.
+type App struct {
+	Name string // e.g. "Slack", used as profile name
+
+	// user data directories relative to the OS specific base directories
+	ConfigDirs [][]string // "${XDG_CONFIG_HOME:-$HOME/.config}", "$HOME/Library/Application Support", "%AppData%"
+	CacheDirs  [][]string // "${XDG_CACHE_HOME:-$HOME/.cache}", "$HOME/Library/Caches", "%LocalAppData%" (CEF apps)
+
+	// Safe Storage
+	Account         string // app name used for the encryption key, defaults to Name
+	KeychainAccount string // macOS Keychain account, defaults to Account + " Key"
+	Application     string // Secret Service "application" attribute, defaults to the lower case Account
+}
+
+var (
+	apps = []*App{
+		{Name: `Discord`, ConfigDirs: [][]string{{`discord`}}, Account: `discord`},
+		{Name: `Element`, ConfigDirs: [][]string{{`Element`}}},
+		{
+			Name:       `Microsoft Teams`,
+			ConfigDirs: [][]string{{`Microsoft`, `Microsoft Teams`}, {`Microsoft`, `Teams`}},
+		},
+		{Name: `Signal`, ConfigDirs: [][]string{{`Signal`}}},
+		{Name: `Slack`, ConfigDirs: [][]string{{`Slack`}}},
+		{
+			Name:      `Spotify`,
+			CacheDirs: [][]string{{`spotify`, `Browser`}, {`com.spotify.client`, `Browser`}, {`Spotify`, `Browser`}},
+		},
+		{Name: `Visual Studio Code`, ConfigDirs: [][]string{{`Code`}}, Account: `Code`},
+	}
+	muApps sync.RWMutex
+)
+
+// RegisterApp() adds an app to the registry or replaces the app with the same name.
+func RegisterApp(app *App) {
+	if app == nil || len(app.Name) == 0 {
+		return
+	}
+	muApps.Lock()
+	defer muApps.Unlock()
+	apps = slices.DeleteFunc(apps, func(a *App) bool { return a.Name == app.Name })
+	apps = append(apps, app)
+}
+
+// Apps() returns the registered apps.
+func Apps() []*App {
+	muApps.RLock()
+	defer muApps.RUnlock()
+	return slices.Clone(apps)
+}
+
+// setSafeStorage configures the decryption of the cookie store.
+func (a *App) setSafeStorage(s *chrome.CookieStore) {
+	account := a.Account
+	if len(account) == 0 {
+		account = a.Name
+	}
+	if runtime.GOOS == `darwin` {
+		// Electron uses "<app name> Key" as account of the "<app name> Safe Storage" Keychain item
+		keychainAccount := a.KeychainAccount
+		if len(keychainAccount) == 0 {
+			keychainAccount = account + ` Key`
+		}
+		s.SetSafeStorage(keychainAccount, account+` Safe Storage`, a.Application)
+		return
+	}
+	s.SetSafeStorage(account, ``, a.Application)
+}
+
+// appForPath returns the registered app with a user data directory in path or nil.
+func appForPath(path string) *App {
+	path = filepath.ToSlash(path)
+	for _, app := range Apps() {
+		for _, dir := range slices.Concat(app.ConfigDirs, app.CacheDirs) {
+			if strings.Contains(path, `/`+strings.Join(dir, `/`)+`/`) {
+				return app
+			}
+		}
+	}
+	return nil
+}
+
+func ReadCookies(ctx context.Context, filename string, filters ...kooky.Filter) ([]*kooky.Cookie, error) {
+	return cookies.SingleRead(cookieStore, filename, filters...).ReadAllCookies(ctx)
+}
+
+func TraverseCookies(filename string, filters ...kooky.Filter) kooky.CookieSeq {
+	return cookies.SingleRead(cookieStore, filename, filters...)
+}
+
+// CookieStore has to be closed with CookieStore.Close() after use.
+//
+// The app is determined by the path of the file.
+func CookieStore(filename string, filters ...kooky.Filter) (kooky.CookieStore, error) {
+	return cookieStore(filename, filters...)
+}
+
+func cookieStore(filename string, filters ...kooky.Filter) (*cookies.CookieJar, error) {
+	s := &chrome.CookieStore{}
+	s.FileNameStr = filename
+	s.BrowserStr = `electron`
+	if app := appForPath(filename); app != nil {
+		s.ProfileStr = app.Name
+		app.setSafeStorage(s)
+	}
+
+	return cookies.NewCookieJar(s, filters...), nil
+}
@@ -0,0 +1,78 @@
+package electron
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/browserutils/kooky"
+)
+
+func TestFindCookieStores(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`test uses the Linux user data directory layout`)
+	}
+	cfgDir := t.TempDir()
+	t.Setenv(`XDG_CONFIG_HOME`, cfgDir)
+	t.Setenv(`XDG_CACHE_HOME`, t.TempDir())
+
+	RegisterApp(&App{Name: `Test App`, ConfigDirs: [][]string{{`TestApp`}}})
+	root := filepath.Join(cfgDir, `TestApp`)
+	for _, dir := range []string{
+		filepath.Join(root, `Network`),
+		filepath.Join(root, `Partitions`, `work`),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{
+		filepath.Join(root, `Network`, `Cookies`),
+		filepath.Join(root, `Partitions`, `work`, `Cookies`),
+	} {
+		if err := os.WriteFile(file, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Electron writes a "Local State" file without the profile info_cache
+	if err := os.WriteFile(filepath.Join(root, `Local State`), []byte(`{"os_crypt":{}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]string{}
+	for st, err := range kooky.Finder(`electron`).FindCookieStores() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Browser() != `electron` {
+			t.Errorf(`got browser %q; want "electron"`, st.Browser())
+		}
+		if _, err := os.Stat(st.FilePath()); err == nil {
+			got[st.Profile()] = st.FilePath()
+		}
+		st.Close()
+	}
+	want := map[string]string{
+		`Test App`:      filepath.Join(root, `Network`, `Cookies`),
+		`Test App/work`: filepath.Join(root, `Partitions`, `work`, `Cookies`),
+	}
+	for profile, path := range want {
+		if got[profile] != path {
+			t.Errorf(`profile %q: got cookie store file %q; want %q`, profile, got[profile], path)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf(`got cookie stores %v; want %v`, got, want)
+	}
+}
+
+func TestAppForPath(t *testing.T) {
+	app := appForPath(filepath.Join(`home`, `user`, `.config`, `Slack`, `Network`, `Cookies`))
+	if app == nil || app.Name != `Slack` {
+		t.Errorf(`got app %+v; want Slack`, app)
+	}
+	if app := appForPath(filepath.Join(`home`, `user`, `.config`, `chromium`, `Default`, `Cookies`)); app != nil {
+		t.Errorf(`got app %q for a browser profile`, app.Name)
+	}
+}
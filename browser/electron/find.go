@@ -0,0 +1,140 @@
+package electron
+
+import (
+	"iter"
+	"os"
+	"path/filepath"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+	"github.com/browserutils/kooky/internal/chrome/find"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/windowsx"
+)
+
+type electronFinder struct{}
+
+var (
+	_ kooky.CookieStoreFinder = (*electronFinder)(nil)
+	_ kooky.ProfileFinder     = (*electronFinder)(nil)
+)
+
+func init() {
+	kooky.RegisterFinder(`electron`, &electronFinder{})
+}
+
+func (f *electronFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for _, app := range Apps() {
+			for file, err := range find.FindCookieStoreFiles(app.roots, `electron`) {
+				if err != nil {
+					if !yield(nil, err) {
+						return
+					}
+					continue
+				}
+				if file == nil {
+					continue
+				}
+				profileDir := filepath.Dir(file.Path)
+				if filepath.Base(profileDir) == `Network` {
+					profileDir = filepath.Dir(profileDir)
+				}
+				profile, isDefault := app.profileName(profileDir)
+				cookieStore := &chrome.CookieStore{
+					DefaultCookieStore: cookies.DefaultCookieStore{
+						BrowserStr:           file.Browser,
+						ProfileStr:           profile,
+						OSStr:                file.OS,
+						IsDefaultProfileBool: isDefault,
+						FileNameStr:          file.Path,
+					},
+				}
+				app.setSafeStorage(cookieStore)
+				if !yield(&cookies.CookieJar{CookieStore: cookieStore}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (f *electronFinder) FindProfiles() kooky.ProfileSeq {
+	return func(yield func(*kooky.Profile, error) bool) {
+		for _, app := range Apps() {
+			profiles := func(yield func(*find.Profile, error) bool) {
+				for p, err := range find.FindProfiles(app.roots, `electron`) {
+					if p != nil {
+						p.Name, p.IsDefaultProfile = app.profileName(p.Path)
+					}
+					if !yield(p, err) {
+						return
+					}
+				}
+			}
+			for p, err := range chrome.KookyProfiles(profiles) {
+				if !yield(p, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// profileName returns the profile name for the user data directory or a session partition of the app.
+func (a *App) profileName(dir string) (name string, isDefault bool) {
+	if filepath.Base(filepath.Dir(dir)) == `Partitions` {
+		return a.Name + `/` + filepath.Base(dir), false
+	}
+	return a.Name, true
+}
+
+// roots yields the existing user data directories of the app and their session partitions.
+func (a *App) roots(yield func(string, error) bool) {
+	var dirs []string
+	join := func(base string, paths [][]string) {
+		for _, pathParts := range paths {
+			dirs = append(dirs, filepath.Join(append([]string{base}, pathParts...)...))
+		}
+	}
+	if cfgDir, err := os.UserConfigDir(); err == nil {
+		join(cfgDir, a.ConfigDirs)
+	}
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		join(cacheDir, a.CacheDirs)
+	}
+	// on WSL Linux add Windows paths
+	if windowsx.IsWSL() {
+		if appData, err := windowsx.AppData(); err == nil {
+			join(appData, a.ConfigDirs)
+		}
+		if locApp, err := windowsx.LocalAppData(); err == nil {
+			join(locApp, a.CacheDirs)
+		}
+	}
+	for root := range existingDirs(dirs) {
+		if !yield(root, nil) {
+			return
+		}
+		// persistent sessions of "persist:<name>" partitions
+		entries, _ := os.ReadDir(filepath.Join(root, `Partitions`))
+		for _, entry := range entries {
+			if entry.IsDir() && !yield(filepath.Join(root, `Partitions`, entry.Name()), nil) {
+				return
+			}
+		}
+	}
+}
+
+func existingDirs(dirs []string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, dir := range dirs {
+			if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+				continue
+			}
+			if !yield(dir) {
+				return
+			}
+		}
+	}
+}
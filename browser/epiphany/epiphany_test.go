@@ -0,0 +1,50 @@
+package epiphany
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestFindCookieStores(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`test uses the Linux data directory layout`)
+	}
+	home := t.TempDir()
+	dataHome := filepath.Join(home, `.local`, `share`)
+	t.Setenv(`HOME`, home)
+	t.Setenv(`XDG_DATA_HOME`, dataHome)
+	for _, dir := range []string{
+		filepath.Join(dataHome, `epiphany`),
+		filepath.Join(home, `.var`, `app`, `org.gnome.Epiphany.Canary`, `data`, `epiphany`),
+		filepath.Join(dataHome, `org.gnome.Epiphany.WebApp_0123abcd`),
+	} {
+		testutils.CopyTestDataFile(t, `webkitgtk-cookies.sqlite`, filepath.Join(dir, `cookies.sqlite`))
+	}
+
+	ctx := context.Background()
+	got := map[string]int{}
+	for st, err := range kooky.Finder(`epiphany`).FindCookieStores() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		cookies, err := st.TraverseCookies().ReadAllCookies(ctx)
+		st.Close()
+		if err != nil {
+			continue // other flatpaks
+		}
+		got[st.Profile()] = len(cookies)
+	}
+	for _, profile := range []string{`default`, `canary`, `web app 0123abcd`} {
+		if got[profile] != 2 {
+			t.Errorf(`profile %q: got %d cookies; want 2`, profile, got[profile])
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf(`got profiles %v; want 3`, got)
+	}
+}
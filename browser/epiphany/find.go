@@ -5,9 +5,12 @@ package epiphany
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/browserutils/kooky"
 	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/sandbox"
+	"github.com/browserutils/kooky/internal/webkitgtk"
 )
 
 type epiphanyFinder struct{}
@@ -26,14 +29,14 @@ func (f *epiphanyFinder) FindCookieStores() kooky.CookieStoreSeq {
 			return
 		}
 
-		last := len(roots) - 1
-		for i, root := range roots {
+		for _, root := range roots {
 			st := &cookies.CookieJar{
-				CookieStore: &epiphanyCookieStore{
+				CookieStore: &webkitgtk.CookieStore{
 					DefaultCookieStore: cookies.DefaultCookieStore{
 						BrowserStr:           `epiphany`,
-						IsDefaultProfileBool: i == last,
-						FileNameStr:          filepath.Join(root, `cookies.sqlite`),
+						ProfileStr:           root.profile,
+						IsDefaultProfileBool: root.isDefault,
+						FileNameStr:          filepath.Join(root.dir, `cookies.sqlite`),
 					},
 				},
 			}
@@ -45,16 +48,33 @@ func (f *epiphanyFinder) FindCookieStores() kooky.CookieStoreSeq {
 	}
 }
 
-func epiphanyRoots() ([]string, error) {
-	var ret []string
+type epiphanyRoot struct {
+	dir       string
+	profile   string
+	isDefault bool
+}
+
+// web apps have their own profile directory
+const webAppPrefix = `org.gnome.Epiphany.WebApp_`
+
+func epiphanyRoots() ([]epiphanyRoot, error) {
+	dataHome, err := webkitgtk.DataHome()
+	if err != nil {
+		return nil, err
+	}
+	ret := []epiphanyRoot{{dir: filepath.Join(dataHome, `epiphany`), profile: `default`, isDefault: true}}
 	if home, err := os.UserHomeDir(); err == nil {
-		ret = []string{
-			filepath.Join(home, `.var`, `app`, `org.gnome.Epiphany`, `data`, `epiphany`), // flatpak
-			filepath.Join(home, `.local`, `share`, `epiphany`),                           // fallback
+		for _, app := range []struct{ id, profile string }{
+			{`org.gnome.Epiphany`, `flatpak`},
+			{`org.gnome.Epiphany.Devel`, `devel`},
+			{`org.gnome.Epiphany.Canary`, `canary`},
+		} {
+			ret = append(ret, epiphanyRoot{dir: sandbox.FlatpakDir(home, app.id, `data`, `epiphany`), profile: app.profile})
 		}
 	}
-	if dataDir, ok := os.LookupEnv(`XDG_DATA_HOME`); ok {
-		ret = append(ret, filepath.Join(dataDir, `epiphany`))
+	webApps, _ := filepath.Glob(filepath.Join(dataHome, webAppPrefix+`*`))
+	for _, dir := range webApps {
+		ret = append(ret, epiphanyRoot{dir: dir, profile: `web app ` + strings.TrimPrefix(filepath.Base(dir), webAppPrefix)})
 	}
 
 	return ret, nil
@@ -0,0 +1,42 @@
+package falkon
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/browserutils/kooky"
+)
+
+func TestFindProfiles(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`test uses the Linux config directory layout`)
+	}
+	cfgDir := t.TempDir()
+	t.Setenv(`XDG_CONFIG_HOME`, cfgDir)
+	root := filepath.Join(cfgDir, `falkon`, `profiles`)
+	for _, p := range []string{`default`, `work`} {
+		if err := os.MkdirAll(filepath.Join(root, p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, `profiles.ini`), []byte("[Profiles]\nstartProfile=work\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]bool{}
+	for st, err := range kooky.Finder(`falkon`).FindCookieStores() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(root, st.Profile(), `Cookies`); st.FilePath() != want {
+			t.Errorf(`got cookie store file %q; want %q`, st.FilePath(), want)
+		}
+		got[st.Profile()] = st.IsDefaultProfile()
+		st.Close()
+	}
+	if len(got) != 2 || got[`default`] || !got[`work`] {
+		t.Errorf(`got profiles (name: is default) %v; want "work" as default of 2 profiles`, got)
+	}
+}
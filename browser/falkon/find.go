@@ -0,0 +1,125 @@
+package falkon
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+	"github.com/browserutils/kooky/internal/cookies"
+
+	"gopkg.in/ini.v1"
+)
+
+type falkonFinder struct{}
+
+var (
+	_ kooky.CookieStoreFinder = (*falkonFinder)(nil)
+	_ kooky.ProfileFinder     = (*falkonFinder)(nil)
+)
+
+func init() {
+	kooky.RegisterFinder(`falkon`, &falkonFinder{})
+}
+
+type profile struct {
+	name, dir string
+	isDefault bool
+}
+
+func (f *falkonFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		profiles, err := findProfiles()
+		if err != nil {
+			_ = yield(nil, err)
+			return
+		}
+		for _, p := range profiles {
+			s := chrome.NewQtWebEngineCookieStore(`falkon`, p.name, p.isDefault, filepath.Join(p.dir, `Cookies`))
+			if !yield(&cookies.CookieJar{CookieStore: s}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (f *falkonFinder) FindProfiles() kooky.ProfileSeq {
+	return func(yield func(*kooky.Profile, error) bool) {
+		profiles, err := findProfiles()
+		if err != nil {
+			_ = yield(nil, err)
+			return
+		}
+		for _, p := range profiles {
+			kp := &kooky.Profile{
+				Browser:     `falkon`,
+				Name:        p.name,
+				Dir:         p.dir,
+				IsDefault:   p.isDefault,
+				DisplayName: p.name,
+			}
+			if !yield(kp, nil) {
+				return
+			}
+		}
+	}
+}
+
+// findProfiles lists the profile directories next to the profiles.ini file
+// which names the default profile ("startProfile").
+func findProfiles() ([]profile, error) {
+	root, err := profilesDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	startProfile := `default`
+	if cfg, err := ini.Load(filepath.Join(root, `profiles.ini`)); err == nil {
+		if p := cfg.Section(`Profiles`).Key(`startProfile`).String(); len(p) > 0 {
+			startProfile = p
+		}
+	}
+	var profiles []profile
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		profiles = append(profiles, profile{
+			name:      entry.Name(),
+			dir:       filepath.Join(root, entry.Name()),
+			isDefault: entry.Name() == startProfile,
+		})
+	}
+	return profiles, nil
+}
+
+// profilesDir returns the directory of the profiles in the QStandardPaths::ConfigLocation.
+func profilesDir() (string, error) {
+	switch runtime.GOOS {
+	case `windows`:
+		// "%LocalAppData%\falkon\profiles"
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return ``, err
+		}
+		return filepath.Join(cacheDir, `falkon`, `profiles`), nil
+	case `darwin`:
+		// "$HOME/Library/Preferences/falkon/profiles"
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ``, err
+		}
+		return filepath.Join(home, `Library`, `Preferences`, `falkon`, `profiles`), nil
+	default:
+		// "${XDG_CONFIG_HOME:-$HOME/.config}/falkon/profiles"
+		cfgDir, err := os.UserConfigDir()
+		if err != nil {
+			return ``, err
+		}
+		return filepath.Join(cfgDir, `falkon`, `profiles`), nil
+	}
+}
@@ -8,7 +8,11 @@ import (
 
 type firefoxFinder struct{}
 
-var _ kooky.CookieStoreFinder = (*firefoxFinder)(nil)
+var (
+	_ kooky.CookieStoreFinder = (*firefoxFinder)(nil)
+	_ kooky.ProfileFinder     = (*firefoxFinder)(nil)
+	_ kooky.RootFinder        = (*firefoxFinder)(nil)
+)
 
 func init() {
 	kooky.RegisterFinder(`firefox`, &firefoxFinder{})
@@ -17,3 +21,12 @@ func init() {
 func (f *firefoxFinder) FindCookieStores() kooky.CookieStoreSeq {
 	return firefox.CookieStoresForProfiles(find.FindFirefoxProfiles())
 }
+
+func (f *firefoxFinder) FindProfiles() kooky.ProfileSeq {
+	return firefox.KookyProfiles(find.FindFirefoxProfiles())
+}
+
+// FindCookieStoresIn() finds the Firefox for Android (Fenix) cookie stores in Android app data extracted to root.
+func (f *firefoxFinder) FindCookieStoresIn(root string) kooky.CookieStoreSeq {
+	return firefox.FindFenixCookieStores(root)
+}
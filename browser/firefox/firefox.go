@@ -17,6 +17,9 @@ func TraverseCookies(filename string, filters ...kooky.Filter) kooky.CookieSeq {
 }
 
 // CookieStore has to be closed with CookieStore.Close() after use.
+//
+// The first-party domain of first-party isolation (Tor Browser, privacy.firstparty.isolate)
+// is stored as "FirstPartyDomain=example.com" in http.Cookie.Unparsed.
 func CookieStore(filename string, filters ...kooky.Filter) (kooky.CookieStore, error) {
 	return cookieStore(filename, filters...)
 }
@@ -2,10 +2,17 @@ package firefox
 
 import (
 	"context"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/browserutils/kooky"
 	"github.com/browserutils/kooky/internal/testutils"
+	_ "github.com/browserutils/kooky/sqlitewrite"
 )
 
 func TestReadCookies(t *testing.T) {
@@ -106,3 +113,127 @@ func TestReadCookies(t *testing.T) {
 		t.Errorf("c.Value=%q", c.Value)
 	}
 }
+
+func TestFindCookieStoresInAndroidAppData(t *testing.T) {
+	// copy of /data/data with Firefox for Android (Fenix) and Nightly
+	root := t.TempDir()
+	for _, pkg := range []string{`org.mozilla.firefox`, `org.mozilla.fenix`} {
+		mozDir := filepath.Join(root, pkg, `files`, `mozilla`)
+		testutils.CopyTestDataFile(t, `firefox-cookies.sqlite`, filepath.Join(mozDir, `abcd1234.default`, `cookies.sqlite`))
+		profilesIni := "[Profile0]\nName=default\nIsRelative=1\nPath=abcd1234.default\nDefault=1\n"
+		if err := os.WriteFile(filepath.Join(mozDir, `profiles.ini`), []byte(profilesIni), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	got := map[string]bool{}
+	for st, err := range kooky.Finder(`firefox`).(kooky.RootFinder).FindCookieStoresIn(root) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		cookies, err := st.TraverseCookies().ReadAllCookies(ctx)
+		st.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cookies) != 1 || cookies[0].Name != `GODOC_ORG_SESSION_ID` {
+			t.Errorf(`%s: unexpected cookies %v`, st.FilePath(), cookies)
+		}
+		got[st.Profile()] = st.IsDefaultProfile()
+	}
+	want := map[string]bool{`org.mozilla.firefox`: true, `org.mozilla.fenix`: false}
+	if !maps.Equal(got, want) {
+		t.Errorf(`got profiles %v; want %v`, got, want)
+	}
+}
+
+func TestSyncIntoCookiesSQLite(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, `cookies.sqlite`)
+	testutils.CopyTestDataFile(t, `firefox-v82-linux-cookies.sqlite`, filename)
+	containers := `{"version":4,"lastUserContextId":2,"identities":[{"userContextId":2,"public":true,"l10nID":"user-context-work","icon":"briefcase","color":"orange"}]}`
+	if err := os.WriteFile(filepath.Join(dir, `containers.json`), []byte(containers), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	src := kooky.Cookies{
+		{Cookie: http.Cookie{Domain: `.google.de`, Path: `/`, Name: `NID`, Value: `updated`, Expires: expires, Secure: true}},
+		{Cookie: http.Cookie{Domain: `example.com`, Path: `/`, Name: `work`, Value: `w`, Expires: expires}, Container: `Work`},
+		{Cookie: http.Cookie{Domain: `.example.net`, Path: `/`, Name: `chips`, Value: `c`, Expires: expires, Secure: true, Partitioned: true}, PartitionKey: `https://example.org`},
+		{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `session`, Value: `s`}},
+	}
+	seq := func(yield func(*kooky.Cookie, error) bool) {
+		for _, c := range src {
+			if !yield(c, nil) {
+				return
+			}
+		}
+	}
+
+	ctx := context.Background()
+	st, err := CookieStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := kooky.Sync(ctx, seq, st, nil)
+	st.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Written) != 3 || len(report.Skipped) != 1 || report.Skipped[0].Cookie.Name != `session` {
+		t.Fatalf("got %d written, skipped %v", len(report.Written), report.Skipped)
+	}
+
+	cookies, err := TraverseCookies(filename).ReadAllCookies(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]*kooky.Cookie)
+	for _, c := range cookies {
+		if _, ok := got[c.Name+c.Domain]; ok {
+			t.Errorf("cookie %q of %q stored twice", c.Name, c.Domain)
+		}
+		got[c.Name+c.Domain] = c
+		got[c.Name] = c
+	}
+	if c := got[`NID.google.de`]; c == nil || c.Value != `updated` || !c.Expires.Equal(expires) {
+		t.Errorf("cookie NID not updated: %v", c)
+	}
+	if c := got[`work`]; c == nil || c.Container != `Work` {
+		t.Errorf("container cookie: %v", c)
+	}
+	if c := got[`chips`]; c == nil || !c.Partitioned || c.PartitionKey != `https://example.org` {
+		t.Errorf("partitioned cookie: %v", c)
+	}
+}
+
+func TestFirstPartyIsolation(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), `cookies.sqlite`)
+	testutils.CopyTestDataFile(t, `firefox-v82-linux-cookies.sqlite`, filename)
+
+	st, err := CookieStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &kooky.Cookie{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `fpi`, Value: `1`, Expires: time.Now().Add(time.Hour)}}
+	c.Unparsed = []string{`FirstPartyDomain=example.org`}
+	err = st.(kooky.CookieWriter).WriteCookies(c)
+	st.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cookies, err := ReadCookies(context.Background(), filename, kooky.Name(`fpi`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 1 {
+		t.Fatalf("got %d cookies, want 1", len(cookies))
+	}
+	// first-party isolation is not partitioning
+	if got := cookies[0]; got.Partitioned || len(got.PartitionKey) > 0 || !slices.Equal(got.Unparsed, c.Unparsed) {
+		t.Errorf("got partitioned %t, partition key %q, unparsed %q", got.Partitioned, got.PartitionKey, got.Unparsed)
+	}
+}
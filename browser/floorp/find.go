@@ -0,0 +1,10 @@
+package floorp
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+)
+
+func init() {
+	kooky.RegisterFinder(`floorp`, &firefox.ForkFinder{Fork: fork})
+}
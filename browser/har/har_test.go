@@ -0,0 +1,49 @@
+package har
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestReadCookies(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath("example.har")
+	if err != nil {
+		t.Fatalf("Failed to load test data file")
+	}
+
+	cookies, err := ReadCookies(context.Background(), testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 2 {
+		for _, c := range cookies {
+			t.Logf("%s %s%s", c.Name, c.Domain, c.Path)
+		}
+		t.Fatalf("got %d cookies, but expected 2", len(cookies))
+	}
+
+	// sent with a request but not set during the recording
+	c := cookies[0]
+	if c.Name != `legacy` || c.Domain != `www.example.com` || c.Path != `/` || !c.Expires.IsZero() {
+		t.Errorf("wrong cookie %q: domain %q, path %q, expires %v", c.Name, c.Domain, c.Path, c.Expires)
+	}
+	if c.Browser == nil || c.Browser.FilePath() != testCookiesPath {
+		t.Errorf("got file path %q", c.Browser.FilePath())
+	}
+
+	// replaced by the second response, which comes first in the file
+	c = cookies[1]
+	if c.Name != `sid` || c.Value != `two` || c.Domain != `.example.com` || c.Path != `/` || c.SameSite != http.SameSiteLaxMode {
+		t.Errorf("wrong cookie %+v", c.Cookie)
+	}
+	if want := time.Date(2024, 5, 1, 11, 1, 0, 0, time.UTC); !c.Expires.Equal(want) {
+		t.Errorf("got expiry %v, want %v (Max-Age relative to the entry)", c.Expires, want)
+	}
+	if want := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC); !c.Creation.Equal(want) {
+		t.Errorf("got creation time %v, want %v", c.Creation, want)
+	}
+}
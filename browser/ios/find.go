@@ -0,0 +1,40 @@
+package ios
+
+import (
+	"github.com/browserutils/kooky"
+)
+
+type iosFinder struct{}
+
+var (
+	_ kooky.CookieStoreFinder = (*iosFinder)(nil)
+	_ kooky.RootFinder        = (*iosFinder)(nil)
+)
+
+func init() {
+	kooky.RegisterFinder(`ios`, &iosFinder{})
+}
+
+// FindCookieStores() finds the cookie stores in the iTunes/Finder backup directories.
+func (f *iosFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for dir, err := range backupDirs {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			for st, err := range BackupCookieStores(dir) {
+				if !yield(st, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FindCookieStoresIn() finds the cookie stores in the backup root or in the backups below it.
+func (f *iosFinder) FindCookieStoresIn(root string) kooky.CookieStoreSeq {
+	return BackupCookieStores(root)
+}
@@ -0,0 +1,17 @@
+//go:build darwin && !ios
+
+package ios
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func backupDirs(yield func(string, error) bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		_ = yield(``, err)
+		return
+	}
+	_ = yield(filepath.Join(home, `Library`, `Application Support`, `MobileSync`, `Backup`), nil)
+}
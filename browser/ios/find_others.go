@@ -0,0 +1,6 @@
+//go:build !(darwin && !ios) && !windows
+
+package ios
+
+// backups copied from other systems are found with kooky.AlternateRoot()
+func backupDirs(yield func(string, error) bool) {}
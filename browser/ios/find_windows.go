@@ -0,0 +1,28 @@
+//go:build windows
+
+package ios
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/browserutils/kooky/internal/windowsx"
+)
+
+func backupDirs(yield func(string, error) bool) {
+	// iTunes installer
+	if appData, err := windowsx.AppData(); err == nil {
+		if !yield(filepath.Join(appData, `Apple Computer`, `MobileSync`, `Backup`), nil) {
+			return
+		}
+	} else if !yield(``, err) {
+		return
+	}
+	// iTunes from the Microsoft Store, Apple Devices app
+	home, err := os.UserHomeDir()
+	if err != nil {
+		_ = yield(``, err)
+		return
+	}
+	_ = yield(filepath.Join(home, `Apple`, `MobileSync`, `Backup`), nil)
+}
@@ -0,0 +1,65 @@
+// Package ios reads the Cookies.binarycookies files of Safari and apps
+// from unencrypted iTunes/Finder backups of iOS devices.
+//
+// Each app with cookies is a profile named by its bundle ID,
+// Safari and the other system apps of the HomeDomain are "com.apple.mobilesafari".
+package ios
+
+import (
+	"path"
+	"strings"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/iosbackup"
+	"github.com/browserutils/kooky/internal/safari"
+)
+
+const safariBundleID = `com.apple.mobilesafari`
+
+// BackupCookieStores() yields the cookie stores of the backup in backupDir
+// or of the backups in its subdirectories.
+func BackupCookieStores(backupDir string) kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for dir := range iosbackup.Backups(backupDir) {
+			for file, err := range iosbackup.Files(dir, iosbackup.IsCookieFile) {
+				if err != nil {
+					if !yield(nil, err) {
+						return
+					}
+					continue
+				}
+				profile := profileName(file)
+				st := &cookies.CookieJar{
+					CookieStore: &safari.CookieStore{
+						DefaultCookieStore: cookies.DefaultCookieStore{
+							BrowserStr:           `ios`,
+							ProfileStr:           profile,
+							OSStr:                `ios`,
+							IsDefaultProfileBool: profile == safariBundleID && file.Domain == `HomeDomain`,
+							FileNameStr:          file.Path,
+						},
+					},
+				}
+				if !yield(st, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func profileName(file *iosbackup.File) string {
+	if id := file.BundleID(); len(id) > 0 {
+		return id
+	}
+	// HomeDomain: "Cookies.binarycookies" of Safari, "<bundleID>.binarycookies" of other system apps
+	name := strings.TrimSuffix(path.Base(file.RelativePath), `.binarycookies`)
+	if name == `Cookies` {
+		if file.Domain == `HomeDomain` {
+			return safariBundleID
+		}
+		return file.Domain
+	}
+	return name
+}
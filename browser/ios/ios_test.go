@@ -0,0 +1,44 @@
+package ios
+
+import (
+	"context"
+	"maps"
+	"path/filepath"
+	"testing"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestBackupCookieStores(t *testing.T) {
+	// MobileSync/Backup/<UDID> with files named by the SHA-1 of "<domain>-<relativePath>"
+	backup := filepath.Join(t.TempDir(), `00008030-001A2B3C4D5E6F70`)
+	testutils.CopyTestDataFile(t, `ios-backup-Manifest.db`, filepath.Join(backup, `Manifest.db`))
+	for _, id := range []string{
+		`fdda2f81cc0b838dc00e3050b14da7ef2d835f3c`, // HomeDomain
+		`5e4986b18507bd7d06aba3cb3e83c4963637a1d1`, // AppDomain-com.example.reader
+	} {
+		testutils.CopyTestDataFile(t, `safari-macos-cookie-db.binarycookies`, filepath.Join(backup, id[:2], id))
+	}
+
+	ctx := context.Background()
+	got := map[string]bool{}
+	for st, err := range kooky.Finder(`ios`).(kooky.RootFinder).FindCookieStoresIn(filepath.Dir(backup)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		cookies, err := st.TraverseCookies(kooky.Domain(`news.ycombinator.com`), kooky.Name(`user`)).ReadAllCookies(ctx)
+		st.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cookies) != 1 {
+			t.Errorf(`%s: got %d cookies; want 1`, st.FilePath(), len(cookies))
+		}
+		got[st.Profile()] = st.IsDefaultProfile()
+	}
+	want := map[string]bool{`com.apple.mobilesafari`: true, `com.example.reader`: false}
+	if !maps.Equal(got, want) {
+		t.Errorf(`got profiles %v; want %v`, got, want)
+	}
+}
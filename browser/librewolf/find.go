@@ -0,0 +1,10 @@
+package librewolf
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+)
+
+func init() {
+	kooky.RegisterFinder(`librewolf`, &firefox.ForkFinder{Fork: fork})
+}
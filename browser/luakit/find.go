@@ -0,0 +1,40 @@
+//go:build !windows && !android && !ios
+
+package luakit
+
+import (
+	"path/filepath"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/webkitgtk"
+)
+
+type luakitFinder struct{}
+
+var _ kooky.CookieStoreFinder = (*luakitFinder)(nil)
+
+func init() {
+	kooky.RegisterFinder(`luakit`, &luakitFinder{})
+}
+
+func (f *luakitFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		// luakit.data_dir: "${XDG_DATA_HOME:-$HOME/.local/share}/luakit"
+		dataHome, err := webkitgtk.DataHome()
+		if err != nil {
+			_ = yield(nil, err)
+			return
+		}
+		st := &cookies.CookieJar{
+			CookieStore: &webkitgtk.CookieStore{
+				DefaultCookieStore: cookies.DefaultCookieStore{
+					BrowserStr:           `luakit`,
+					IsDefaultProfileBool: true,
+					FileNameStr:          filepath.Join(dataHome, `luakit`, `cookies.db`),
+				},
+			},
+		}
+		_ = yield(st, nil)
+	}
+}
@@ -0,0 +1,39 @@
+package luakit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestReadCookies(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath(`webkitgtk-cookies.sqlite`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	cookies, err := ReadCookies(ctx, testCookiesPath, kooky.Name(`sid`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 1 {
+		t.Fatalf(`got %d cookies; want 1`, len(cookies))
+	}
+	c := cookies[0]
+	if c.Domain != `.webkitgtk.org` || c.Path != `/` || c.Value != `webkit-session` {
+		t.Errorf(`unexpected cookie %+v`, c.Cookie)
+	}
+	if !c.Secure || !c.HttpOnly || c.SameSite != http.SameSiteStrictMode {
+		t.Errorf(`unexpected cookie attributes %+v`, c.Cookie)
+	}
+	if want := time.Unix(2000000000, 0); !c.Expires.Equal(want) {
+		t.Errorf(`got expiry %v; want %v`, c.Expires, want)
+	}
+	if c.Browser.Browser() != `luakit` {
+		t.Errorf(`got browser %q`, c.Browser.Browser())
+	}
+}
@@ -0,0 +1,46 @@
+// Package lwp reads and writes the cookie files of Python's http.cookiejar.LWPCookieJar.
+//
+// Python's MozillaCookieJar files are handled by the netscape package.
+package lwp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/lwp"
+	"github.com/browserutils/kooky/internal/utils"
+)
+
+func ReadCookies(ctx context.Context, filename string, filters ...kooky.Filter) ([]*kooky.Cookie, error) {
+	return cookies.SingleRead(cookieStore, filename, filters...).ReadAllCookies(ctx)
+}
+
+func TraverseCookies(filename string, filters ...kooky.Filter) kooky.CookieSeq {
+	return cookies.SingleRead(cookieStore, filename, filters...)
+}
+
+// CookieStore has to be closed with CookieStore.Close() after use.
+//
+// The cookie store implements kooky.CookieWriter.
+func CookieStore(filename string, filters ...kooky.Filter) (kooky.CookieStore, error) {
+	return cookieStore(filename, filters...)
+}
+
+func cookieStore(filename string, filters ...kooky.Filter) (*cookies.CookieJar, error) {
+	f, typ, err := utils.DetectFileType(filename)
+	if err != nil {
+		return nil, err
+	}
+	if typ != `lwp` {
+		f.Close()
+		return nil, fmt.Errorf(`%s: %w`, filename, lwp.ErrNotLWP)
+	}
+	s := &lwp.CookieStore{}
+	s.File = f
+	s.FileNameStr = filename
+	s.BrowserStr = `lwp`
+
+	return cookies.NewCookieJar(s, filters...), nil
+}
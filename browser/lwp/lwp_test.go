@@ -0,0 +1,88 @@
+package lwp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/netscape"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestReadCookies(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath("lwp-cookies.txt")
+	if err != nil {
+		t.Fatalf("Failed to load test data file")
+	}
+
+	cookies, err := ReadCookies(context.Background(), testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 3 {
+		t.Fatalf("got %d cookies, but expected 3", len(cookies))
+	}
+
+	c := cookies[0]
+	if c.Name != `sid` || c.Value != `abc 123` || c.Domain != `.example.com` || c.Path != `/` || !c.Secure || !c.HttpOnly {
+		t.Errorf("wrong cookie %+v", c.Cookie)
+	}
+	if !c.Expires.Equal(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("c.Expires=%v", c.Expires)
+	}
+	c = cookies[1]
+	if !c.Expires.IsZero() {
+		t.Errorf("session cookie with expiry %v", c.Expires)
+	}
+	for _, attr := range []string{`port=80,8080`, `discard`, `comment=UI theme`, `version=1`} {
+		if !slices.Contains(c.Unparsed, attr) {
+			t.Errorf("attribute %q missing in %q", attr, c.Unparsed)
+		}
+	}
+	c = cookies[2]
+	if c.SameSite != http.SameSiteLaxMode || slices.Contains(c.Unparsed, `SameSite=Lax`) {
+		t.Errorf("c.SameSite=%v, c.Unparsed=%q", c.SameSite, c.Unparsed)
+	}
+}
+
+// netscape files converted to LWP keep HttpOnly and the SameSite column of libsoup
+func TestExportNetscape(t *testing.T) {
+	const cookiesTxt = "# HTTP Cookie File\n" +
+		"#HttpOnly_.example.com\tTRUE\t/\tTRUE\t4102444800\tsid\tabc\tStrict\n" +
+		"example.org\tFALSE\t/\tFALSE\t0\tlang\ten\n"
+	seq, _ := netscape.TraverseCookies(strings.NewReader(cookiesTxt), nil)
+
+	var buf bytes.Buffer
+	kooky.ExportCookiesLWP(context.Background(), &buf, seq)
+	want := "#LWP-Cookies-2.0\n" +
+		`Set-Cookie3: sid=abc; path="/"; domain=".example.com"; path_spec; domain_dot; secure; expires="2100-01-01 00:00:00Z"; HttpOnly=None; SameSite=Strict; version=0` + "\n" +
+		`Set-Cookie3: lang=en; path="/"; domain="example.org"; path_spec; discard; version=0` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// Python's LWPCookieJar.save() writes the same file
+func TestExportRoundTrip(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath("lwp-cookies.txt")
+	if err != nil {
+		t.Fatalf("Failed to load test data file")
+	}
+	want, err := os.ReadFile(testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	ctx := context.Background()
+	kooky.ExportCookiesLWP(ctx, &buf, TraverseCookies(testCookiesPath))
+	if got := buf.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
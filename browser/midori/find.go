@@ -0,0 +1,50 @@
+//go:build !windows && !android && !ios
+
+package midori
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/sandbox"
+	"github.com/browserutils/kooky/internal/webkitgtk"
+)
+
+type midoriFinder struct{}
+
+var _ kooky.CookieStoreFinder = (*midoriFinder)(nil)
+
+func init() {
+	kooky.RegisterFinder(`midori`, &midoriFinder{})
+}
+
+func (f *midoriFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		// "${XDG_CONFIG_HOME:-$HOME/.config}/midori"
+		cfgDir, err := os.UserConfigDir()
+		if err != nil {
+			_ = yield(nil, err)
+			return
+		}
+		files := []string{filepath.Join(cfgDir, `midori`, `cookies.db`)}
+		if home, err := os.UserHomeDir(); err == nil {
+			files = append(files, sandbox.FlatpakConfigDir(home, `org.midori_browser.Midori`, `midori`, `cookies.db`))
+		}
+		for i, file := range files {
+			st := &cookies.CookieJar{
+				CookieStore: &webkitgtk.CookieStore{
+					DefaultCookieStore: cookies.DefaultCookieStore{
+						BrowserStr:           `midori`,
+						IsDefaultProfileBool: i == 0,
+						FileNameStr:          file,
+					},
+				},
+			}
+			if !yield(st, nil) {
+				return
+			}
+		}
+	}
+}
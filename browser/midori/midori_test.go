@@ -0,0 +1,41 @@
+package midori
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestFindCookieStores(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`test uses the Linux config directory layout`)
+	}
+	cfgDir := t.TempDir()
+	t.Setenv(`XDG_CONFIG_HOME`, cfgDir)
+	t.Setenv(`HOME`, t.TempDir())
+	testutils.CopyTestDataFile(t, `webkitgtk-cookies.sqlite`, filepath.Join(cfgDir, `midori`, `cookies.db`))
+
+	ctx := context.Background()
+	var found int
+	for st, err := range kooky.Finder(`midori`).FindCookieStores() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		cookies, err := st.TraverseCookies().ReadAllCookies(ctx)
+		st.Close()
+		if err != nil {
+			continue // flatpak location
+		}
+		found++
+		if len(cookies) != 2 || !st.IsDefaultProfile() {
+			t.Errorf(`got %d cookies from %s (default: %t); want 2 from the default profile`, len(cookies), st.FilePath(), st.IsDefaultProfile())
+		}
+	}
+	if found != 1 {
+		t.Errorf(`found %d readable cookie stores; want 1`, found)
+	}
+}
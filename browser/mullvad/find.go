@@ -0,0 +1,10 @@
+package mullvad
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+)
+
+func init() {
+	kooky.RegisterFinder(`mullvad`, &firefox.ForkFinder{Fork: fork})
+}
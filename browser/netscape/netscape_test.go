@@ -2,9 +2,15 @@ package netscape
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/browserutils/kooky"
 	"github.com/browserutils/kooky/internal/testutils"
 )
 
@@ -59,3 +65,68 @@ func TestReadCookies(t *testing.T) {
 		t.Error("c.Secure expected true")
 	}
 }
+
+func TestPersist(t *testing.T) {
+	dir := t.TempDir()
+	storePath := filepath.Join(dir, `cookies.txt`)
+	future := time.Now().Add(time.Hour).Unix()
+	content := fmt.Sprintf("# HTTP Cookie File\n.example.com\tTRUE\t/\tFALSE\t%d\tkeep\tv1\n.example.com\tTRUE\t/\tFALSE\t%d\tgone\tv2\n", future, future)
+	if err := os.WriteFile(storePath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(`http://www.example.com/a/b`)
+	ctx := context.Background()
+
+	for _, sidecar := range []string{``, filepath.Join(dir, `sidecar.txt`)} {
+		st, err := CookieStore(storePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pst, ok := st.(kooky.PersistentCookieStore)
+		if !ok {
+			t.Fatal(`cookie store does not implement kooky.PersistentCookieStore`)
+		}
+		if err := pst.Persist(sidecar); err != nil {
+			t.Fatal(err)
+		}
+		pst.SetCookies(u, []*http.Cookie{
+			{Name: `new`, Value: `v3`, MaxAge: 60},
+			{Name: `gone`, Domain: `example.com`, Path: `/`, MaxAge: -1},
+			// rejected by the jar
+			{Name: `foreign`, Value: `x`, Domain: `bank.example`, MaxAge: 60},
+			{Name: `suffix`, Value: `x`, Domain: `com`, MaxAge: 60},
+			{Name: `insecure`, Value: `x`, Secure: true, MaxAge: 60},
+		})
+		st.Close()
+
+		target := sidecar
+		if len(target) == 0 {
+			target = storePath
+		}
+		seq, _ := TraverseCookies(target)
+		cookies, err := seq.ReadAllCookies(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]*kooky.Cookie)
+		for _, c := range cookies {
+			got[c.Name] = c
+		}
+		if len(sidecar) == 0 && got[`keep`] == nil {
+			t.Error(`existing cookie "keep" lost`)
+		}
+		if got[`gone`] != nil {
+			t.Error(`deleted cookie "gone" still stored`)
+		}
+		for _, name := range []string{`foreign`, `suffix`, `insecure`} {
+			if got[name] != nil {
+				t.Errorf(`rejected cookie %q stored`, name)
+			}
+		}
+		if c := got[`new`]; c == nil {
+			t.Error(`cookie "new" not stored`)
+		} else if c.Domain != `www.example.com` || c.Path != `/a` || c.Expires.IsZero() {
+			t.Errorf(`cookie "new" stored with domain %q, path %q, expiry %v`, c.Domain, c.Path, c.Expires)
+		}
+	}
+}
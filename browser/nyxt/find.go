@@ -0,0 +1,63 @@
+//go:build !windows && !android && !ios
+
+package nyxt
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/netscape"
+	"github.com/browserutils/kooky/internal/sandbox"
+	"github.com/browserutils/kooky/internal/webkitgtk"
+)
+
+type nyxtFinder struct{}
+
+var _ kooky.CookieStoreFinder = (*nyxtFinder)(nil)
+
+func init() {
+	kooky.RegisterFinder(`nyxt`, &nyxtFinder{})
+}
+
+func (f *nyxtFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		dataHome, err := webkitgtk.DataHome()
+		if err != nil {
+			_ = yield(nil, err)
+			return
+		}
+		dataDirs := []string{filepath.Join(dataHome, `nyxt`)}
+		if home, err := os.UserHomeDir(); err == nil {
+			dataDirs = append(dataDirs, sandbox.FlatpakDir(home, `engineer.atlas.Nyxt`, `data`, `nyxt`))
+		}
+		for i, dataDir := range dataDirs {
+			// the default profile's cookies are directly in the data directory,
+			// those of other profiles (nyxt --profile <name>) in subdirectories
+			st := nyxtCookieStore(filepath.Join(dataDir, `cookies.txt`), `default`, i == 0)
+			if !yield(st, nil) {
+				return
+			}
+			profileFiles, _ := filepath.Glob(filepath.Join(dataDir, `*`, `cookies.txt`))
+			for _, file := range profileFiles {
+				if !yield(nyxtCookieStore(file, filepath.Base(filepath.Dir(file)), false), nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func nyxtCookieStore(file, profile string, isDefault bool) *cookies.CookieJar {
+	return &cookies.CookieJar{
+		CookieStore: &netscape.CookieStore{
+			DefaultCookieStore: cookies.DefaultCookieStore{
+				BrowserStr:           `nyxt`,
+				ProfileStr:           profile,
+				IsDefaultProfileBool: isDefault,
+				FileNameStr:          file,
+			},
+		},
+	}
+}
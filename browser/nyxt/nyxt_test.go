@@ -0,0 +1,39 @@
+package nyxt
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestFindCookieStores(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`test uses the Linux data directory layout`)
+	}
+	dataHome := t.TempDir()
+	t.Setenv(`XDG_DATA_HOME`, dataHome)
+	t.Setenv(`HOME`, t.TempDir())
+	testutils.CopyTestDataFile(t, `webkitgtk-cookies.txt`, filepath.Join(dataHome, `nyxt`, `cookies.txt`))
+	testutils.CopyTestDataFile(t, `webkitgtk-cookies.txt`, filepath.Join(dataHome, `nyxt`, `work`, `cookies.txt`))
+
+	ctx := context.Background()
+	got := map[string]int{}
+	for st, err := range kooky.Finder(`nyxt`).FindCookieStores() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		cookies, err := st.TraverseCookies().ReadAllCookies(ctx)
+		st.Close()
+		if err != nil {
+			continue // flatpak location
+		}
+		got[st.Profile()] = len(cookies)
+	}
+	if len(got) != 2 || got[`default`] != 2 || got[`work`] != 2 {
+		t.Errorf(`got cookie counts per profile %v; want 2 for "default" and "work"`, got)
+	}
+}
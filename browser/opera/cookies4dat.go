@@ -1,12 +1,12 @@
 package opera
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
-	"math/bits"
-	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +15,23 @@ import (
 	"github.com/browserutils/kooky/internal/iterx"
 )
 
+// "cookies4.dat" format of Opera 4 to 12 (Presto)
+//
+// The file header is followed by a tree of records:
+//
+//	domain record (component of the host name, top-level domain first)
+//	    cookie records of the path "/"
+//	    path records (component of the path)
+//	        cookie records
+//	        path records ...
+//	    path end
+//	    domain records of subdomains ...
+//	domain end
+//
+// Each record starts with a tag ID. Tag IDs with the most significant bit set are flags
+// without payload, all other tags are followed by the payload length and the payload.
+// The payload of domain, path and cookie records holds their fields as records.
+
 type fileHeader struct {
 	FileVersionNumber uint32
 	AppVersionNumber  uint32
@@ -22,7 +39,56 @@ type fileHeader struct {
 	LengthLength      uint16
 }
 
-// "cookies4.dat" format
+// file format 1.0 is used by all Opera versions from 4 to 12,
+// newer minor versions only add tags which are skipped
+const (
+	fileVersionMajor = 1
+	fileVersion      = fileVersionMajor<<12 | 0
+	appVersion       = 2<<12 | 1 // Opera 9 - 12
+)
+
+// attribute names in http.Cookie.Unparsed for values without a Cookie field;
+// those shared with LWP files are named like there
+const (
+	attrComment        = `comment`
+	attrCommentURL     = `commenturl`
+	attrPort           = `port`
+	attrVersion        = `version`
+	attrLastUsed       = `opera-last-used`
+	attrReceivedDomain = `opera-received-domain`
+	attrReceivedPath   = `opera-received-path`
+	attrProtected      = `opera-protected`
+	attrPathPrefix     = `opera-path-prefix`
+	attrPasswordLogin  = `opera-password-login`
+	attrHTTPAuth       = `opera-http-auth`
+	attrThirdParty     = `opera-third-party`
+)
+
+// set in the first byte of the tag ID of records without payload
+const flagTagLengthMarker = 0x80
+
+var cookieStringTags = []struct {
+	tagID uint32
+	attr  string
+}{
+	{tagIDCookieRFC2965Comment, attrComment},
+	{tagIDCookieRFC2965CommentURL, attrCommentURL},
+	{tagIDCookieRFC2965CommentVersion1Domain, attrReceivedDomain},
+	{tagIDCookieRFC2965CommentVersion1Path, attrReceivedPath},
+	{tagIDCookieRFC2965CommentVersion1PortLim, attrPort},
+}
+
+var cookieFlagTags = []struct {
+	tagID uint32
+	attr  string
+}{
+	{tagIDCookieDeleteProtection, attrProtected},
+	{tagIDCookiePathPrefixFilter, attrPathPrefix},
+	{tagIDCookiePasswordLogin, attrPasswordLogin},
+	{tagIDCookieHTTPAuth, attrHTTPAuth},
+	{tagIDCookie3rdParty, attrThirdParty},
+}
+
 func (s *operaPrestoCookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
 	if s == nil {
 		return iterx.ErrCookieSeq(errors.New(`cookie store is nil`))
@@ -32,6 +98,10 @@ func (s *operaPrestoCookieStore) TraverseCookies(filters ...kooky.Filter) kooky.
 			yield(nil, err)
 			return
 		}
+		if s.File == nil {
+			yield(nil, errors.New(`file is nil`))
+			return
+		}
 		if _, err := s.File.Seek(0, io.SeekStart); err != nil {
 			yield(nil, err)
 			return
@@ -44,188 +114,207 @@ func (s *operaPrestoCookieStore) TraverseCookies(filters ...kooky.Filter) kooky.
 		}
 		fileFormatVersionMajor := hdr.FileVersionNumber >> 12
 		fileFormatVersionMinor := hdr.FileVersionNumber & 0xfff
-		if fileFormatVersionMajor != 1 || fileFormatVersionMinor != 0 {
+		if fileFormatVersionMajor != fileVersionMajor {
 			yield(nil, errors.New(`unsupported file format version `+
 				strconv.Itoa(int(fileFormatVersionMajor))+`.`+strconv.Itoa(int(fileFormatVersionMinor))))
 			return
 		}
-		// appVersionMajor := hdr.AppVersionNumber >> 12
-		// appVersionMinor := hdr.AppVersionNumber & 0xfff
+		if hdr.IDTagLength < 1 || hdr.IDTagLength > 4 || hdr.LengthLength < 1 || hdr.LengthLength > 4 {
+			yield(nil, errors.New(`unexpected byte length values`))
+			return
+		}
 
 		p := &processor{
-			browser:      s,
-			reader:       s.File,
-			idTagLength:  hdr.IDTagLength,
-			lengthLength: hdr.LengthLength,
-			filters:      filters,
+			browser: s,
+			records: &recordReader{
+				reader:       s.File,
+				idTagLength:  hdr.IDTagLength,
+				lengthLength: hdr.LengthLength,
+			},
 		}
 		yld := func(cookie *kooky.Cookie, err error) bool {
 			return iterx.CookieFilterYield(context.Background(), cookie, err, yield, filters...)
 		}
-		_, err := p.process(yld)
-		if err != nil {
-			if !p.end && !errors.Is(err, iterx.ErrYieldEnd) && !errors.Is(err, io.EOF) {
-				_ = yld(nil, err)
-			}
-			return
-		}
-		if p.end || !p.yieldCookie(yld) {
-			return
+		if err := p.process(yld); err != nil && !errors.Is(err, iterx.ErrYieldEnd) {
+			_ = yld(nil, err)
 		}
 	}
 }
 
 type processor struct {
-	reader        io.Reader
-	idTagLength   uint16
-	lengthLength  uint16
-	tagID         uint32
-	payloadLength uint32
-	domainParts   []string
-	onlyToSource  bool
-	path          string
-	cookie        *kooky.Cookie
-	filters       []kooky.Filter
-	browser       kooky.BrowserInfo
-	end           bool
-}
-
-func (p *processor) yieldCookie(yield func(*kooky.Cookie, error) bool) bool {
-	if p.cookie == nil {
-		return true
-	}
-	if !p.onlyToSource {
-		p.cookie.Domain = `.` + p.cookie.Domain
-	}
-	return yield(p.cookie, nil)
+	records     *recordReader
+	domainParts []string // top-level domain first
+	pathParts   []string
+	browser     kooky.BrowserInfo
 }
 
-func (p *processor) process(yield func(*kooky.Cookie, error) bool) (int, error) {
-	if p.idTagLength < 1 || p.idTagLength > 4 || p.lengthLength < 1 || p.lengthLength > 4 {
-		err := errors.New(`unexpected byte length values`)
-		if !yield(nil, err) {
-			p.end = true
-		}
-		return 0, err
-	}
-
-	var totalN int
+func (p *processor) process(yield func(*kooky.Cookie, error) bool) error {
 	for {
-		n, tagID, payloadLength, err := getRecord(p.reader, p.idTagLength, p.lengthLength)
-		totalN += n
-		isEOF := errors.Is(err, io.EOF)
-		if isEOF {
-			p.tagID = tagID
-			p.payloadLength = payloadLength
-			return totalN, err
+		tagID, payload, err := p.records.next()
+		if errors.Is(err, io.EOF) {
+			return nil
 		}
 		if err != nil {
-			return totalN, err
-		}
-		p.tagID = tagID
-		p.payloadLength = payloadLength
-
-		var payload []byte
-		if payloadLength > 0 {
-			switch p.tagID {
-			case tagIDDomainStart, tagIDPathStart, tagIDCookie:
-			default:
-				payload = make([]byte, payloadLength)
-				n2, err := io.ReadFull(p.reader, payload)
-				totalN += n2
-				if err != nil {
-					return totalN, err
-				}
-			}
+			return err
 		}
 		switch tagID {
-		case tagIDCookie:
-			c := &kooky.Cookie{}
-			reversed := slices.Clone(p.domainParts)
-			slices.Reverse(reversed)
-			c.Domain = strings.Join(reversed, `.`)
-			c.Path = p.path
-			c.Browser = p.browser
-			if !p.yieldCookie(yield) {
-				p.end = true
-				return totalN, nil
+		case tagIDDomainStart:
+			fields, err := p.records.fields(payload)
+			if err != nil {
+				return err
 			}
-			p.cookie = c
-			p.onlyToSource = false
-		case tagIDDomainName:
-			p.domainParts = append(p.domainParts, string(payload))
+			p.domainParts = append(p.domainParts, string(fields.value(tagIDDomainName)))
 		case tagIDDomainEnd:
+			// the top level is closed by a domain end as well
 			if len(p.domainParts) > 0 {
 				p.domainParts = p.domainParts[:len(p.domainParts)-1]
 			}
-		case tagIDPathName:
-			p.path = string(payload)
-		case tagIDPathStart, tagIDPathEnd:
-			p.path = ``
-		case tagIDCookieName:
-			if p.cookie != nil {
-				p.cookie.Name = string(payload)
+			// a domain contains its paths
+			p.pathParts = nil
+		case tagIDPathStart:
+			fields, err := p.records.fields(payload)
+			if err != nil {
+				return err
 			}
-		case tagIDCookieValue:
-			if p.cookie != nil {
-				p.cookie.Value = string(payload)
+			p.pathParts = append(p.pathParts, string(fields.value(tagIDPathName)))
+		case tagIDPathEnd:
+			if len(p.pathParts) > 0 {
+				p.pathParts = p.pathParts[:len(p.pathParts)-1]
 			}
-		case tagIDCookieDateExpiry:
-			if len(payload) == 8 && p.cookie != nil {
-				p.cookie.Expires = time.Unix(int64(binary.BigEndian.Uint64(payload)), 0)
+		case tagIDCookie:
+			fields, err := p.records.fields(payload)
+			if err != nil {
+				return err
 			}
-		case tagIDCookieHTTPSOnly:
-			if p.cookie != nil {
-				p.cookie.Secure = true
+			if !yield(p.cookie(fields), nil) {
+				return iterx.ErrYieldEnd
 			}
-		case tagIDCookieOnlyToSource:
-			p.onlyToSource = true
 		}
 	}
 }
 
-func getRecord(s io.Reader, idTagLength, lengthLength uint16) (n int, tagID, payloadLength uint32, e error) {
-	tagIDBytes := make([]byte, idTagLength)
-	m, err := s.Read(tagIDBytes)
-	n += m
-	if err != nil {
-		return n, 0, 0, err
+// cookie builds the cookie from the fields of a cookie record at the current position in the tree.
+func (p *processor) cookie(fields recordFields) *kooky.Cookie {
+	c := &kooky.Cookie{}
+	c.Domain = joinDomain(p.domainParts)
+	if !fields.has(tagIDCookieOnlyToSource) {
+		c.Domain = `.` + c.Domain
+	}
+	c.Path = `/` + strings.Join(p.pathParts, `/`)
+	c.Name = string(fields.value(tagIDCookieName))
+	c.Value = string(fields.value(tagIDCookieValue))
+	if v, ok := fields.get(tagIDCookieDateExpiry); ok {
+		c.Expires = time.Unix(int64(toUint64(v)), 0)
+	}
+	c.Secure = fields.has(tagIDCookieHTTPSOnly)
+	if v, ok := fields.get(tagIDCookieDateLastUsed); ok {
+		c.Unparsed = append(c.Unparsed, attrLastUsed+`=`+strconv.FormatUint(toUint64(v), 10))
+	}
+	for _, t := range cookieStringTags {
+		if v, ok := fields.get(t.tagID); ok {
+			c.Unparsed = append(c.Unparsed, t.attr+`=`+string(v))
+		}
+	}
+	if v, ok := fields.get(tagIDCookieRFC2965Version); ok {
+		c.Unparsed = append(c.Unparsed, attrVersion+`=`+strconv.FormatUint(toUint64(v), 10))
+	}
+	for _, t := range cookieFlagTags {
+		if fields.has(t.tagID) {
+			c.Unparsed = append(c.Unparsed, t.attr)
+		}
+	}
+	c.Browser = p.browser
+	return c
+}
+
+// joinDomain joins the domain components stored top-level domain first.
+func joinDomain(parts []string) string {
+	var b strings.Builder
+	for i := len(parts) - 1; i >= 0; i-- {
+		b.WriteString(parts[i])
+		if i > 0 {
+			b.WriteByte('.')
+		}
 	}
+	return b.String()
+}
 
-	noLength := bits.LeadingZeros8(tagIDBytes[0]) == 0
-	if noLength {
-		tagIDBytes[0] &= 0x7f // remove most significant bit
+type recordReader struct {
+	reader       io.Reader
+	idTagLength  uint16
+	lengthLength uint16
+}
+
+// next reads the tag ID and the payload of the next record.
+func (r *recordReader) next() (tagID uint32, payload []byte, _ error) {
+	tagIDBytes := make([]byte, r.idTagLength)
+	if _, err := io.ReadFull(r.reader, tagIDBytes); err != nil {
+		return 0, nil, err
 	}
-	tagID = toUint32(tagIDBytes)
+
+	// most significant bit
+	noLength := tagIDBytes[0]&flagTagLengthMarker != 0
+	tagIDBytes[0] &^= flagTagLengthMarker
+	tagID = uint32(toUint64(tagIDBytes))
 	if noLength {
-		return n, tagID, 0, nil
+		return tagID, nil, nil
 	}
 
-	payloadLengthBytes := make([]byte, lengthLength)
-	m, err = s.Read(payloadLengthBytes)
-	n += m
-	if err != nil {
-		return n, 0, 0, err
+	payloadLengthBytes := make([]byte, r.lengthLength)
+	if _, err := io.ReadFull(r.reader, payloadLengthBytes); err != nil {
+		return 0, nil, unexpectedEOF(err)
+	}
+	payload = make([]byte, toUint64(payloadLengthBytes))
+	if _, err := io.ReadFull(r.reader, payload); err != nil {
+		return 0, nil, fmt.Errorf("reading payload of tag %#x: %w", tagID, unexpectedEOF(err))
 	}
-	payloadLength = toUint32(payloadLengthBytes)
 
-	return n, tagID, payloadLength, nil
+	return tagID, payload, nil
 }
 
-func toUint32(b []byte) uint32 {
-	if l := len(b); l > 4 || l < 1 {
-		panic(`unexpected byte length values`)
+// recordFields are the fields in the payload of a domain, path or cookie record.
+type recordFields map[uint32][]byte
+
+func (f recordFields) get(tagID uint32) ([]byte, bool) { v, ok := f[tagID]; return v, ok }
+func (f recordFields) value(tagID uint32) []byte       { return f[tagID] }
+func (f recordFields) has(tagID uint32) bool           { _, ok := f[tagID]; return ok }
+
+func (r *recordReader) fields(payload []byte) (recordFields, error) {
+	sub := &recordReader{
+		reader:       bytes.NewReader(payload),
+		idTagLength:  r.idTagLength,
+		lengthLength: r.lengthLength,
 	}
-	length := len(b)
-	switch length {
-	case 1:
-		b = append([]byte{0x00, 0x00, 0x00}, b...)
-	case 2:
-		b = append([]byte{0x00, 0x00}, b...)
-	case 3:
-		b = append([]byte{0x00}, b...)
+	fields := make(recordFields)
+	for {
+		tagID, v, err := sub.next()
+		if errors.Is(err, io.EOF) {
+			return fields, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			v = []byte{}
+		}
+		fields[tagID] = v
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// toUint64 decodes big endian unsigned integers of up to 8 bytes.
+func toUint64(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
 	}
-	return binary.BigEndian.Uint32(b)
+	return v
 }
 
 const (
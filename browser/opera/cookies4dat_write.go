@@ -0,0 +1,281 @@
+package opera
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+var _ kooky.CookieWriter = (*operaPrestoCookieStore)(nil)
+
+// WriteCookies merges the cookies into the cookies4.dat file of the cookie store.
+func (s *operaPrestoCookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	// the file is replaced
+	if err := s.Close(); err != nil {
+		return err
+	}
+	return WriteCookies4Dat(s.FileNameStr, kookies...)
+}
+
+// WriteCookies4Dat() merges the cookies into the Opera Presto cookies4.dat file filename.
+// The file is created if it does not exist.
+//
+// The cookie filters of the domains in an existing file are not kept.
+func WriteCookies4Dat(filename string, updates ...*kooky.Cookie) error {
+	var existing []*kooky.Cookie
+	if _, err := os.Stat(filename); err == nil {
+		s := &operaPrestoCookieStore{}
+		s.FileNameStr = filename
+		existing, err = s.TraverseCookies().ReadAllCookies(context.Background())
+		s.Close()
+		if err != nil {
+			return err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	merged := cookies.MergeCookies(existing, updates, time.Now())
+
+	// write to a temporary file first so that readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(filename), `.`+filepath.Base(filename)+`.*`)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := EncodeCookies4Dat(tmp, merged...); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// EncodeCookies4Dat() writes the cookies in the cookies4.dat format of Opera 9 - 12.
+//
+// Attributes without a Cookie field are taken from http.Cookie.Unparsed,
+// where they are stored when reading cookies4.dat or LWP files.
+func EncodeCookies4Dat(w io.Writer, kookies ...*kooky.Cookie) error {
+	root := &domainNode{}
+	for _, c := range kookies {
+		if c == nil || len(c.Domain) == 0 {
+			continue
+		}
+		root.add(c)
+	}
+
+	e := &recordWriter{}
+	_ = binary.Write(&e.buf, binary.BigEndian, fileHeader{
+		FileVersionNumber: fileVersion,
+		AppVersionNumber:  appVersion,
+		IDTagLength:       1,
+		LengthLength:      2,
+	})
+	for _, d := range root.sorted() {
+		if err := e.domain(d); err != nil {
+			return err
+		}
+	}
+	// end of the top level
+	e.flag(tagIDDomainEnd)
+
+	_, err := w.Write(e.buf.Bytes())
+	return err
+}
+
+// domainNode is a component of a host name with the cookies of its paths and its subdomains.
+type domainNode struct {
+	name       string
+	root       pathNode
+	subdomains map[string]*domainNode
+}
+
+type pathNode struct {
+	name     string
+	cookies  []*kooky.Cookie
+	children map[string]*pathNode
+}
+
+func (d *domainNode) add(c *kooky.Cookie) {
+	node := d
+	labels := strings.Split(strings.TrimPrefix(c.Domain, `.`), `.`)
+	for i := len(labels) - 1; i >= 0; i-- {
+		if node.subdomains == nil {
+			node.subdomains = make(map[string]*domainNode)
+		}
+		sub, ok := node.subdomains[labels[i]]
+		if !ok {
+			sub = &domainNode{name: labels[i]}
+			node.subdomains[labels[i]] = sub
+		}
+		node = sub
+	}
+	path := &node.root
+	for part := range strings.SplitSeq(c.Path, `/`) {
+		if len(part) == 0 {
+			continue
+		}
+		if path.children == nil {
+			path.children = make(map[string]*pathNode)
+		}
+		child, ok := path.children[part]
+		if !ok {
+			child = &pathNode{name: part}
+			path.children[part] = child
+		}
+		path = child
+	}
+	path.cookies = append(path.cookies, c)
+}
+
+func (d *domainNode) sorted() []*domainNode {
+	return sortedNodes(d.subdomains, func(n *domainNode) string { return n.name })
+}
+
+func (p *pathNode) sorted() []*pathNode {
+	return sortedNodes(p.children, func(n *pathNode) string { return n.name })
+}
+
+func sortedNodes[N any](m map[string]N, name func(N) string) []N {
+	nodes := make([]N, 0, len(m))
+	for _, n := range m {
+		nodes = append(nodes, n)
+	}
+	slices.SortFunc(nodes, func(a, b N) int { return cmp.Compare(name(a), name(b)) })
+	return nodes
+}
+
+// recordWriter writes records with 1 byte tag IDs and 2 byte payload lengths like Opera 9 - 12.
+type recordWriter struct {
+	buf bytes.Buffer
+}
+
+func (e *recordWriter) record(tagID uint32, payload []byte) error {
+	if len(payload) > 0xffff {
+		return fmt.Errorf("payload of tag %#x too long (%d bytes)", tagID, len(payload))
+	}
+	e.buf.WriteByte(byte(tagID))
+	_ = binary.Write(&e.buf, binary.BigEndian, uint16(len(payload)))
+	e.buf.Write(payload)
+	return nil
+}
+
+func (e *recordWriter) flag(tagID uint32) {
+	e.buf.WriteByte(byte(tagID) | flagTagLengthMarker)
+}
+
+func (e *recordWriter) domain(d *domainNode) error {
+	fields := &recordWriter{}
+	if err := fields.record(tagIDDomainName, []byte(d.name)); err != nil {
+		return err
+	}
+	if err := e.record(tagIDDomainStart, fields.buf.Bytes()); err != nil {
+		return err
+	}
+	// cookies of the path "/"
+	for _, c := range d.root.cookies {
+		if err := e.cookie(c); err != nil {
+			return err
+		}
+	}
+	for _, p := range d.root.sorted() {
+		if err := e.path(p); err != nil {
+			return err
+		}
+	}
+	for _, sub := range d.sorted() {
+		if err := e.domain(sub); err != nil {
+			return err
+		}
+	}
+	e.flag(tagIDDomainEnd)
+	return nil
+}
+
+func (e *recordWriter) path(p *pathNode) error {
+	fields := &recordWriter{}
+	if err := fields.record(tagIDPathName, []byte(p.name)); err != nil {
+		return err
+	}
+	if err := e.record(tagIDPathStart, fields.buf.Bytes()); err != nil {
+		return err
+	}
+	for _, c := range p.cookies {
+		if err := e.cookie(c); err != nil {
+			return err
+		}
+	}
+	for _, child := range p.sorted() {
+		if err := e.path(child); err != nil {
+			return err
+		}
+	}
+	e.flag(tagIDPathEnd)
+	return nil
+}
+
+func (e *recordWriter) cookie(c *kooky.Cookie) error {
+	attrs := make(map[string]string)
+	for _, attr := range c.Unparsed {
+		k, v, _ := strings.Cut(attr, `=`)
+		attrs[strings.ToLower(k)] = strings.Trim(v, `"`)
+	}
+
+	fields := &recordWriter{}
+	var errs []error
+	str := func(tagID uint32, v string) { errs = append(errs, fields.record(tagID, []byte(v))) }
+	num := func(tagID uint32, v uint64, size int) {
+		b := binary.BigEndian.AppendUint64(nil, v)
+		errs = append(errs, fields.record(tagID, b[8-size:]))
+	}
+
+	str(tagIDCookieName, c.Name)
+	str(tagIDCookieValue, c.Value)
+	if !c.Expires.IsZero() {
+		num(tagIDCookieDateExpiry, uint64(c.Expires.Unix()), 8)
+	}
+	if v, err := strconv.ParseUint(attrs[attrLastUsed], 10, 64); err == nil {
+		num(tagIDCookieDateLastUsed, v, 8)
+	}
+	for _, t := range cookieStringTags {
+		if v, ok := attrs[t.attr]; ok {
+			str(t.tagID, v)
+		}
+	}
+	if v, err := strconv.ParseUint(attrs[attrVersion], 10, 8); err == nil {
+		num(tagIDCookieRFC2965Version, v, 1)
+	}
+	if c.Secure {
+		fields.flag(tagIDCookieHTTPSOnly)
+	}
+	if !strings.HasPrefix(c.Domain, `.`) {
+		fields.flag(tagIDCookieOnlyToSource)
+	}
+	for _, t := range cookieFlagTags {
+		if _, ok := attrs[t.attr]; ok {
+			fields.flag(t.tagID)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("cookie %q: %w", c.Name, err)
+	}
+	return e.record(tagIDCookie, fields.buf.Bytes())
+}
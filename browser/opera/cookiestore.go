@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 
+	"github.com/browserutils/kooky"
 	"github.com/browserutils/kooky/internal/cookies"
 	"github.com/browserutils/kooky/internal/utils"
 )
@@ -14,6 +15,22 @@ type operaCookieStore struct {
 
 var _ cookies.CookieStore = (*operaCookieStore)(nil)
 
+func (s *operaCookieStore) Capabilities() kooky.StoreCapabilities {
+	return kooky.CapabilitiesOf(s.CookieStore)
+}
+
+// WriteCookies writes to Presto cookies4.dat files, Blink cookie databases are read-only.
+func (s *operaCookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	w, ok := s.CookieStore.(kooky.CookieWriter)
+	if !ok {
+		return kooky.ErrNotWritable
+	}
+	return w.WriteCookies(kookies...)
+}
+
 type operaPrestoCookieStore struct {
 	cookies.DefaultCookieStore
 }
@@ -11,7 +11,10 @@ import (
 
 type operaFinder struct{}
 
-var _ kooky.CookieStoreFinder = (*operaFinder)(nil)
+var (
+	_ kooky.CookieStoreFinder = (*operaFinder)(nil)
+	_ kooky.ProfileFinder     = (*operaFinder)(nil)
+)
 
 func init() {
 	kooky.RegisterFinder(`opera`, &operaFinder{})
@@ -72,3 +75,7 @@ func (f *operaFinder) FindCookieStores() kooky.CookieStoreSeq {
 		}
 	}
 }
+
+func (f *operaFinder) FindProfiles() kooky.ProfileSeq {
+	return chrome.KookyProfiles(chromefind.FindProfiles(operaBlinkRoots, `opera`))
+}
@@ -22,6 +22,8 @@ func (s *operaCookieStore) TraverseCookies(filters ...kooky.Filter) kooky.Cookie
 }
 
 // CookieStore has to be closed with CookieStore.Close() after use.
+//
+// The cookie store implements kooky.CookieWriter for Presto cookies4.dat files.
 func CookieStore(filename string, filters ...kooky.Filter) (kooky.CookieStore, error) {
 	return cookieStore(filename, filters...)
 }
@@ -34,7 +36,7 @@ func cookieStore(filename string, filters ...kooky.Filter) (*cookies.CookieJar,
 		return nil, err
 	}
 	switch typ {
-	case `opera_cookies4_1.0`: // TODO `presto`
+	case `opera_cookies4`: // Presto
 		p := &operaPrestoCookieStore{}
 		p.File = f
 		p.FileNameStr = filename
@@ -0,0 +1,132 @@
+package opera
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestReadCookies4Dat(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath("opera-cookies4.dat")
+	if err != nil {
+		t.Fatalf("Failed to load test data file")
+	}
+
+	cookies, err := TraverseCookies(testCookiesPath).ReadAllCookies(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 4 {
+		t.Fatalf("got %d cookies, but expected 4", len(cookies))
+	}
+
+	for i, want := range []struct{ name, domain, path string }{
+		{`sid`, `.example.com`, `/`},
+		{`theme`, `example.com`, `/account`},
+		{`lang`, `.example.com`, `/account/settings`},
+		{`host`, `www.example.com`, `/`},
+	} {
+		c := cookies[i]
+		if c.Name != want.name || c.Domain != want.domain || c.Path != want.path {
+			t.Errorf("cookie %d: got %s %s %s, expected %s %s %s", i, c.Name, c.Domain, c.Path, want.name, want.domain, want.path)
+		}
+	}
+
+	c := cookies[0]
+	if c.Value != `abc` || !c.Secure {
+		t.Errorf("wrong cookie %+v", c.Cookie)
+	}
+	if !c.Expires.Equal(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("c.Expires=%v", c.Expires)
+	}
+	checkAttrs(t, cookies[0], `opera-last-used=1700000000`)
+	checkAttrs(t, cookies[1], `comment=UI theme`, `version=1`, `opera-third-party`)
+	checkAttrs(t, cookies[2], `port=80,8080`, `opera-path-prefix`)
+	if !cookies[3].Expires.IsZero() {
+		t.Errorf("session cookie with expiry %v", cookies[3].Expires)
+	}
+}
+
+func TestWriteCookies4Dat(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath("opera-cookies4.dat")
+	if err != nil {
+		t.Fatalf("Failed to load test data file")
+	}
+	ctx := context.Background()
+	want, err := TraverseCookies(testCookiesPath).ReadAllCookies(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(t.TempDir(), `cookies4.dat`)
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := EncodeCookies4Dat(f, want...); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	got, err := TraverseCookies(filename).ReadAllCookies(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d cookies, but expected %d", len(got), len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Name != w.Name || g.Value != w.Value || g.Domain != w.Domain || g.Path != w.Path ||
+			g.Secure != w.Secure || !g.Expires.Equal(w.Expires) || !slices.Equal(g.Unparsed, w.Unparsed) {
+			t.Errorf("cookie %d: got %+v, expected %+v", i, g.Cookie, w.Cookie)
+		}
+	}
+
+	// update a cookie through the cookie store
+	s, err := CookieStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, ok := s.(kooky.CookieWriter)
+	if !ok {
+		t.Fatal(`cookie store is not a kooky.CookieWriter`)
+	}
+	update := &kooky.Cookie{}
+	update.Name = `lang`
+	update.Value = `de`
+	update.Domain = `.example.com`
+	update.Path = `/account/settings`
+	if err := w.WriteCookies(update); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	got, err = TraverseCookies(filename).ReadAllCookies(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d cookies after update, but expected %d", len(got), len(want))
+	}
+	for _, c := range got {
+		if c.Name == `lang` && c.Value != `de` {
+			t.Errorf("cookie not updated: %+v", c.Cookie)
+		}
+	}
+}
+
+func checkAttrs(t *testing.T, c *kooky.Cookie, attrs ...string) {
+	t.Helper()
+	for _, attr := range attrs {
+		if !slices.Contains(c.Unparsed, attr) {
+			t.Errorf("cookie %s: attribute %q missing in %q", c.Name, attr, c.Unparsed)
+		}
+	}
+}
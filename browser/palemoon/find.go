@@ -0,0 +1,10 @@
+package palemoon
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+)
+
+func init() {
+	kooky.RegisterFinder(`palemoon`, &firefox.ForkFinder{Fork: fork})
+}
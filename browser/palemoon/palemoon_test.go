@@ -0,0 +1,74 @@
+package palemoon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestReadCookies(t *testing.T) {
+	// moz_cookies with the baseDomain and appId columns of Pale Moon 29
+	testCookiesPath, err := testutils.GetTestDataFilePath(`palemoon-cookies.sqlite`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies, err := ReadCookies(context.Background(), testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 1 {
+		t.Fatalf(`got %d cookies; want 1`, len(cookies))
+	}
+	c := cookies[0]
+	if c.Domain != `.www.example.org` {
+		t.Errorf(`got domain %q; want the host instead of the base domain`, c.Domain)
+	}
+	if c.Name != `session` || c.Value != `abc123` || !c.Secure || !c.HttpOnly {
+		t.Errorf(`unexpected cookie %+v`, c.Cookie)
+	}
+	if want := time.Unix(1600000000, 0); !c.Creation.Equal(want) {
+		t.Errorf(`got creation time %v; want %v`, c.Creation, want)
+	}
+	if c.Browser.Browser() != `palemoon` {
+		t.Errorf(`got browser %q`, c.Browser.Browser())
+	}
+}
+
+func TestFindCookieStores(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`test uses the Linux profile directory layout`)
+	}
+	home := t.TempDir()
+	t.Setenv(`HOME`, home)
+	root := filepath.Join(home, `.moonchild productions`, `pale moon`)
+	if err := os.MkdirAll(filepath.Join(root, `abcd1234.default`), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	profilesIni := "[General]\nStartWithLastProfile=1\n\n[Profile0]\nName=default\nIsRelative=1\nPath=abcd1234.default\nDefault=1\n"
+	if err := os.WriteFile(filepath.Join(root, `profiles.ini`), []byte(profilesIni), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for st, err := range kooky.Finder(`palemoon`).FindCookieStores() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Browser() != `palemoon` || st.Profile() != `default` || !st.IsDefaultProfile() {
+			t.Errorf(`unexpected cookie store %s/%s (default: %t)`, st.Browser(), st.Profile(), st.IsDefaultProfile())
+		}
+		paths = append(paths, st.FilePath())
+		st.Close()
+	}
+	want := filepath.Join(root, `abcd1234.default`, `cookies.sqlite`)
+	if !slices.Contains(paths, want) {
+		t.Errorf(`got cookie store files %q; want %q`, paths, want)
+	}
+}
@@ -0,0 +1,68 @@
+package qtwebengine
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+type qtWebEngineFinder struct{}
+
+var _ kooky.CookieStoreFinder = (*qtWebEngineFinder)(nil)
+
+func init() {
+	kooky.RegisterFinder(`qtwebengine`, &qtWebEngineFinder{})
+}
+
+// FindCookieStores() finds the cookie stores of the QWebEngineProfile storage of applications
+// in "<data location>/<application>/QtWebEngine/<profile>/Cookies".
+// The profile name is "<application>/<profile>".
+func (f *qtWebEngineFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		dataDir, err := dataLocation()
+		if err != nil {
+			_ = yield(nil, err)
+			return
+		}
+		files, err := filepath.Glob(filepath.Join(dataDir, `*`, `QtWebEngine`, `*`, `Cookies`))
+		if err != nil {
+			_ = yield(nil, err)
+			return
+		}
+		for _, file := range files {
+			profileDir := filepath.Dir(file)
+			app := filepath.Base(filepath.Dir(filepath.Dir(profileDir)))
+			profile := filepath.Base(profileDir)
+			s := chrome.NewQtWebEngineCookieStore(`qtwebengine`, app+`/`+profile, profile == `Default`, file)
+			if !yield(&cookies.CookieJar{CookieStore: s}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// dataLocation returns the QStandardPaths::GenericDataLocation.
+func dataLocation() (string, error) {
+	switch runtime.GOOS {
+	case `windows`:
+		// "%LocalAppData%"
+		return os.UserCacheDir()
+	case `darwin`:
+		// "$HOME/Library/Application Support"
+		return os.UserConfigDir()
+	default:
+		// "${XDG_DATA_HOME:-$HOME/.local/share}"
+		if dir, ok := os.LookupEnv(`XDG_DATA_HOME`); ok && len(dir) > 0 {
+			return dir, nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ``, err
+		}
+		return filepath.Join(home, `.local`, `share`), nil
+	}
+}
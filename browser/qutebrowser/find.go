@@ -0,0 +1,97 @@
+package qutebrowser
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+type qutebrowserFinder struct{}
+
+var _ kooky.CookieStoreFinder = (*qutebrowserFinder)(nil)
+
+func init() {
+	kooky.RegisterFinder(`qutebrowser`, &qutebrowserFinder{})
+}
+
+var (
+	basedirs   []string
+	muBasedirs sync.RWMutex
+)
+
+// RegisterBasedir() adds a directory passed to qutebrowser with "--basedir" to the searched locations.
+// The name of the directory is used as profile name.
+func RegisterBasedir(dir string) {
+	muBasedirs.Lock()
+	defer muBasedirs.Unlock()
+	if len(dir) > 0 && !slices.Contains(basedirs, dir) {
+		basedirs = append(basedirs, dir)
+	}
+}
+
+func (f *qutebrowserFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		type dataDir struct {
+			dir, profile string
+			isDefault    bool
+		}
+		var dataDirs []dataDir
+		if dir, err := defaultDataDir(); err != nil {
+			if !yield(nil, err) {
+				return
+			}
+		} else {
+			dataDirs = append(dataDirs, dataDir{dir: dir, profile: `default`, isDefault: true})
+		}
+		muBasedirs.RLock()
+		for _, basedir := range basedirs {
+			dataDirs = append(dataDirs, dataDir{dir: filepath.Join(basedir, `data`), profile: filepath.Base(basedir)})
+		}
+		muBasedirs.RUnlock()
+
+		for _, d := range dataDirs {
+			filename := filepath.Join(d.dir, `webengine`, `Cookies`)
+			s := chrome.NewQtWebEngineCookieStore(`qutebrowser`, d.profile, d.isDefault, filename)
+			if !yield(&cookies.CookieJar{CookieStore: s}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// defaultDataDir returns the data directory of qutebrowser without "--basedir".
+// https://github.com/qutebrowser/qutebrowser/blob/main/qutebrowser/utils/standarddir.py
+func defaultDataDir() (string, error) {
+	switch runtime.GOOS {
+	case `windows`:
+		// "%AppData%\qutebrowser\data"
+		cfgDir, err := os.UserConfigDir()
+		if err != nil {
+			return ``, err
+		}
+		return filepath.Join(cfgDir, `qutebrowser`, `data`), nil
+	case `darwin`:
+		// "$HOME/Library/Application Support/qutebrowser"
+		cfgDir, err := os.UserConfigDir()
+		if err != nil {
+			return ``, err
+		}
+		return filepath.Join(cfgDir, `qutebrowser`), nil
+	default:
+		// "${XDG_DATA_HOME:-$HOME/.local/share}/qutebrowser"
+		if dir, ok := os.LookupEnv(`XDG_DATA_HOME`); ok && len(dir) > 0 {
+			return filepath.Join(dir, `qutebrowser`), nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ``, err
+		}
+		return filepath.Join(home, `.local`, `share`, `qutebrowser`), nil
+	}
+}
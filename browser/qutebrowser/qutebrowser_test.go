@@ -0,0 +1,45 @@
+package qutebrowser
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestFindCookieStores(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`test uses the Linux data directory layout`)
+	}
+	dataDir := t.TempDir()
+	t.Setenv(`XDG_DATA_HOME`, dataDir)
+
+	testutils.CopyTestDataFile(t, `qtwebengine-cookies.sqlite`, filepath.Join(dataDir, `qutebrowser`, `webengine`, `Cookies`))
+
+	ctx := context.Background()
+	cookies, err := kooky.Finder(`qutebrowser`).FindCookieStores().TraverseCookies(ctx).ReadAllCookies(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 1 {
+		t.Fatalf(`got %d cookies; want 1`, len(cookies))
+	}
+	c := cookies[0]
+	if c.Domain != `.qutebrowser.org` || c.Name != `session` || c.Value != `plain-value` {
+		t.Errorf(`unexpected cookie %+v`, c.Cookie)
+	}
+	if !c.Secure || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode {
+		t.Errorf(`unexpected cookie attributes %+v`, c.Cookie)
+	}
+	if want := time.Unix(2000000000, 0); !c.Expires.Equal(want) {
+		t.Errorf(`got expiry %v; want %v`, c.Expires, want)
+	}
+	if c.Browser.Browser() != `qutebrowser` || c.Browser.Profile() != `default` || !c.Browser.IsDefaultProfile() {
+		t.Errorf(`unexpected cookie store %s/%s`, c.Browser.Browser(), c.Browser.Profile())
+	}
+}
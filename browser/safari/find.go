@@ -5,6 +5,7 @@ package safari
 import (
 	"github.com/browserutils/kooky"
 	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/safari"
 )
 
 type safariFinder struct{}
@@ -25,7 +26,7 @@ func (f *safariFinder) FindCookieStores() kooky.CookieStoreSeq {
 
 		for i, fileStr := range fileStrs {
 			st := &cookies.CookieJar{
-				CookieStore: &safariCookieStore{
+				CookieStore: &safari.CookieStore{
 					DefaultCookieStore: cookies.DefaultCookieStore{
 						BrowserStr:           `safari`,
 						IsDefaultProfileBool: i == 0,
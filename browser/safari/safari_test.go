@@ -1,11 +1,19 @@
 package safari
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/safari"
 	"github.com/browserutils/kooky/internal/testutils"
 )
 
@@ -44,3 +52,122 @@ func TestReadCookies(t *testing.T) {
 		t.Errorf("Want cookie.Creation=%v; got %v", wantCreation, cookie.Creation)
 	}
 }
+
+func TestEncode(t *testing.T) {
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := []*kooky.Cookie{
+		{Cookie: http.Cookie{Name: `sid`, Value: `abc`, Domain: `.example.com`, Path: `/`, Expires: expires, Secure: true, HttpOnly: true, SameSite: http.SameSiteStrictMode}},
+		{Cookie: http.Cookie{Name: `lang`, Value: `en`, Domain: `.example.com`, Path: `/`, Expires: expires, SameSite: http.SameSiteLaxMode}},
+		{Cookie: http.Cookie{Name: `legacy`, Value: `1`, Domain: `www.example.org`, Path: `/app`, Expires: expires, Unparsed: []string{`port=8443`}}},
+	}
+	filename := filepath.Join(t.TempDir(), `Cookies.binarycookies`)
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Encode(f, want...); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	got, err := ReadCookies(ctx, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf(`got %d cookies; want %d`, len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Name != w.Name || g.Value != w.Value || g.Domain != w.Domain || g.Path != w.Path ||
+			!g.Expires.Equal(w.Expires) || g.Secure != w.Secure || g.HttpOnly != w.HttpOnly ||
+			g.SameSite != w.SameSite || !slices.Equal(g.Unparsed, w.Unparsed) {
+			t.Errorf("cookie %d: got %+v; want %+v", i, g.Cookie, w.Cookie)
+		}
+	}
+
+	// update in place
+	st, err := CookieStore(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update := &kooky.Cookie{Cookie: http.Cookie{Name: `lang`, Value: `de`, Domain: `.example.com`, Path: `/`, Expires: expires}}
+	if err := st.(kooky.CookieWriter).WriteCookies(update); err != nil {
+		t.Fatal(err)
+	}
+	st.Close()
+	got, err = ReadCookies(ctx, filename, kooky.Name(`lang`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Value != `de` {
+		t.Errorf(`got %v after update; want lang=de`, got)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath("safari-macos-cookie-db.binarycookies")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// change a byte of the first page which is part of the checksum
+	corrupted := slices.Clone(b)
+	i := bytes.Index(corrupted, []byte(`__cfduid`))
+	i -= i % 4
+	corrupted[i]++
+	filename := filepath.Join(t.TempDir(), `Cookies.binarycookies`)
+	if err := os.WriteFile(filename, corrupted, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadCookies(context.Background(), filename); !errors.Is(err, safari.ErrChecksum) {
+		t.Errorf(`got error %v; want %v`, err, safari.ErrChecksum)
+	}
+}
+
+func TestMalformedFooter(t *testing.T) {
+	testCookiesPath, err := testutils.GetTestDataFilePath("safari-macos-cookie-db.binarycookies")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the footer is the signature, the plist size and the plist
+	footer := bytes.LastIndex(b, []byte{0x07, 0x17, 0x20, 0x05})
+	if footer < 0 {
+		t.Fatal(`no footer in test data`)
+	}
+	trailer := func(offsetTableOffset, numObjects uint64) []byte {
+		tr := make([]byte, 32)
+		tr[6], tr[7] = 1, 1 // offset and object reference size
+		binary.BigEndian.PutUint64(tr[8:], numObjects)
+		binary.BigEndian.PutUint64(tr[24:], offsetTableOffset)
+		return tr
+	}
+	plists := map[string][]byte{
+		// offset table offset + size wraps around
+		`offset table`: append([]byte("bplist00\x08"), trailer(^uint64(0), 1)...),
+		// dictionary with 1<<63 entries referencing itself: 2*count*refSize wraps around
+		`dictionary count`: append([]byte("bplist00\xdf\x13\x80\x00\x00\x00\x00\x00\x00\x00\x00\x08"), trailer(19, 1)...),
+	}
+	for name, plist := range plists {
+		corrupted := append(slices.Clone(b[:footer]), 0x07, 0x17, 0x20, 0x05)
+		corrupted = binary.BigEndian.AppendUint32(corrupted, uint32(len(plist)))
+		corrupted = append(corrupted, plist...)
+		filename := filepath.Join(t.TempDir(), `Cookies.binarycookies`)
+		if err := os.WriteFile(filename, corrupted, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadCookies(context.Background(), filename); err == nil {
+			t.Errorf(`%s: no error for malformed plist`, name)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package seamonkey
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+)
+
+func init() {
+	kooky.RegisterFinder(`seamonkey`, &firefox.ForkFinder{Fork: fork})
+}
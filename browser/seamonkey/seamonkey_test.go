@@ -0,0 +1,34 @@
+package seamonkey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestReadCookies(t *testing.T) {
+	// Firefox 3.0 / SeaMonkey 2.0 schema without the creationTime column
+	testCookiesPath, err := testutils.GetTestDataFilePath(`firefox-v3-cookies.sqlite`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies, err := ReadCookies(context.Background(), testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 1 {
+		t.Fatalf(`got %d cookies; want 1`, len(cookies))
+	}
+	c := cookies[0]
+	if c.Domain != `.example.com` || c.Name != `pref` || c.Value != `lang=en` {
+		t.Errorf(`unexpected cookie %+v`, c.Cookie)
+	}
+	if want := time.Unix(1230000000, 0); !c.Creation.Equal(want) {
+		t.Errorf(`got creation time %v; want %v from the id column`, c.Creation, want)
+	}
+	if want := time.Unix(2000000000, 0); !c.Expires.Equal(want) {
+		t.Errorf(`got expiry %v; want %v`, c.Expires, want)
+	}
+}
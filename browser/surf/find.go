@@ -0,0 +1,52 @@
+//go:build !windows && !android && !ios
+
+package surf
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/netscape"
+	"github.com/browserutils/kooky/internal/webkitgtk"
+)
+
+type surfFinder struct{}
+
+var _ kooky.CookieStoreFinder = (*surfFinder)(nil)
+
+func init() {
+	kooky.RegisterFinder(`surf`, &surfFinder{})
+}
+
+func (f *surfFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		// "cookiefile" of config.def.h
+		var files []string
+		if dataHome, err := webkitgtk.DataHome(); err == nil {
+			files = append(files, filepath.Join(dataHome, `surf`, `cookies.txt`)) // surf 2.1
+		}
+		if home, err := os.UserHomeDir(); err != nil {
+			if !yield(nil, err) {
+				return
+			}
+		} else {
+			files = append(files, filepath.Join(home, `.surf`, `cookies.txt`)) // older versions
+		}
+		for i, file := range files {
+			st := &cookies.CookieJar{
+				CookieStore: &netscape.CookieStore{
+					DefaultCookieStore: cookies.DefaultCookieStore{
+						BrowserStr:           `surf`,
+						IsDefaultProfileBool: i == 0,
+						FileNameStr:          file,
+					},
+				},
+			}
+			if !yield(st, nil) {
+				return
+			}
+		}
+	}
+}
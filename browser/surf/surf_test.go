@@ -0,0 +1,38 @@
+package surf
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestReadCookies(t *testing.T) {
+	// libsoup's text format has the SameSite policy as additional field
+	testCookiesPath, err := testutils.GetTestDataFilePath(`webkitgtk-cookies.txt`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies, err := ReadCookies(context.Background(), testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 2 {
+		t.Fatalf(`got %d cookies; want 2`, len(cookies))
+	}
+	want := []struct {
+		domain, name, value string
+		httpOnly            bool
+		sameSite            http.SameSite
+	}{
+		{`.webkitgtk.org`, `sid`, `webkit-session`, true, http.SameSiteStrictMode},
+		{`www.webkitgtk.org`, `theme`, `dark`, false, http.SameSiteLaxMode},
+	}
+	for i, w := range want {
+		c := cookies[i]
+		if c.Domain != w.domain || c.Name != w.name || c.Value != w.value || c.HttpOnly != w.httpOnly || c.SameSite != w.sameSite {
+			t.Errorf(`cookie %d: got %+v; want %+v`, i, c.Cookie, w)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package thorium
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+)
+
+func init() {
+	kooky.RegisterFinder(`thorium`, &chrome.DerivativeFinder{Derivative: derivative})
+}
@@ -0,0 +1,10 @@
+package tor
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+)
+
+func init() {
+	kooky.RegisterFinder(`tor`, &firefox.ForkFinder{Fork: fork})
+}
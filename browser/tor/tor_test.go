@@ -0,0 +1,32 @@
+package tor
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestReadCookies(t *testing.T) {
+	// Firefox 60 ESR schema with a first-party isolated cookie
+	testCookiesPath, err := testutils.GetTestDataFilePath(`tor-browser-cookies.sqlite`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies, err := ReadCookies(context.Background(), testCookiesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cookies) != 1 {
+		t.Fatalf(`got %d cookies; want 1`, len(cookies))
+	}
+	c := cookies[0]
+	if c.Domain != `.cdn.example.net` || c.Name != `tracker` || c.Value != `xyz` {
+		t.Errorf(`unexpected cookie %+v`, c.Cookie)
+	}
+	// first-party isolation is not partitioning
+	if c.Partitioned || !slices.Equal(c.Unparsed, []string{`FirstPartyDomain=example.com`}) {
+		t.Errorf(`got partitioned %t, unparsed %q; want first party domain "example.com"`, c.Partitioned, c.Unparsed)
+	}
+}
@@ -0,0 +1,10 @@
+package vivaldi
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+)
+
+func init() {
+	kooky.RegisterFinder(`vivaldi`, &chrome.DerivativeFinder{Derivative: derivative})
+}
@@ -0,0 +1,48 @@
+package vivaldi
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/browserutils/kooky"
+)
+
+func TestFindCookieStores(t *testing.T) {
+	if runtime.GOOS != `linux` {
+		t.Skip(`test uses the Linux user data directory layout`)
+	}
+	cfgDir := t.TempDir()
+	t.Setenv(`HOME`, t.TempDir())
+	t.Setenv(`XDG_CONFIG_HOME`, cfgDir)
+	root := filepath.Join(cfgDir, `vivaldi`)
+	if err := os.MkdirAll(filepath.Join(root, `Default`), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	localState := `{"profile":{"info_cache":{"Default":{"name":"Work","is_using_default_name":true}}}}`
+	if err := os.WriteFile(filepath.Join(root, `Local State`), []byte(localState), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for st, err := range kooky.Finder(`vivaldi`).FindCookieStores() {
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // $HOME/.config/vivaldi
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Browser() != `vivaldi` || st.Profile() != `Work` || !st.IsDefaultProfile() {
+			t.Errorf(`unexpected cookie store %s/%s (default: %t)`, st.Browser(), st.Profile(), st.IsDefaultProfile())
+		}
+		paths = append(paths, st.FilePath())
+		st.Close()
+	}
+	want := filepath.Join(root, `Default`, `Network`, `Cookies`)
+	if len(paths) == 0 || paths[0] != want {
+		t.Errorf(`got cookie store files %q; want %q first`, paths, want)
+	}
+}
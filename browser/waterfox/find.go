@@ -0,0 +1,10 @@
+package waterfox
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+)
+
+func init() {
+	kooky.RegisterFinder(`waterfox`, &firefox.ForkFinder{Fork: fork})
+}
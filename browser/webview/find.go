@@ -0,0 +1,29 @@
+package webview
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+)
+
+type webViewFinder struct{}
+
+var (
+	_ kooky.CookieStoreFinder = (*webViewFinder)(nil)
+	_ kooky.RootFinder        = (*webViewFinder)(nil)
+)
+
+func init() {
+	kooky.RegisterFinder(`webview`, &webViewFinder{})
+}
+
+func (f *webViewFinder) FindCookieStores() kooky.CookieStoreSeq {
+	if len(deviceRoot) == 0 {
+		return func(yield func(kooky.CookieStore, error) bool) {}
+	}
+	return f.FindCookieStoresIn(deviceRoot)
+}
+
+// FindCookieStoresIn() finds the WebView cookie stores of the apps in Android app data extracted to root.
+func (f *webViewFinder) FindCookieStoresIn(root string) kooky.CookieStoreSeq {
+	return chrome.FindAndroidWebViewCookieStores(root)
+}
@@ -0,0 +1,6 @@
+//go:build android
+
+package webview
+
+// the app data of other apps is only readable with root access
+const deviceRoot = `/`
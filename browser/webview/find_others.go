@@ -0,0 +1,5 @@
+//go:build !android
+
+package webview
+
+const deviceRoot = ``
@@ -0,0 +1,74 @@
+package webview
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/testutils"
+)
+
+func TestFindCookieStoresIn(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string // relative to the root
+		root  string   // relative to the temporary directory
+		want  []string
+	}{
+		{
+			// unpacked adb backup: app_* directories are in the "r" domain
+			name: `adb backup`,
+			files: []string{
+				`apps/com.example.news/r/app_webview/Default/Cookies`,
+				`apps/org.example.legacy/r/app_webview/Cookies`,
+			},
+			want: []string{`com.example.news`, `org.example.legacy`},
+		},
+		{
+			// device copy in a directory named like a package
+			name:  `device root named like a package`,
+			root:  `phone.dump`,
+			files: []string{`data/data/com.example.news/app_webview/Default/Cookies`},
+			want:  []string{`com.example.news`},
+		},
+		{
+			name:  `copy of /data/data named like a package`,
+			root:  `backup.old`,
+			files: []string{`com.example.news/app_webview/Default/Cookies`},
+			want:  []string{`com.example.news`},
+		},
+		{
+			name:  `single app data directory`,
+			root:  `com.example.news`,
+			files: []string{`app_webview/Default/Cookies`},
+			want:  []string{`com.example.news`},
+		},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		root := filepath.Join(t.TempDir(), tt.root)
+		for _, f := range tt.files {
+			testutils.CopyTestDataFile(t, `android-webview-cookies.sqlite`, filepath.Join(root, filepath.FromSlash(f)))
+		}
+		var profiles []string
+		for st, err := range kooky.Finder(`webview`).(kooky.RootFinder).FindCookieStoresIn(root) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			cookies, err := st.TraverseCookies().ReadAllCookies(ctx)
+			st.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cookies) != 1 || cookies[0].Domain != `.news.example.com` || cookies[0].Value != `webview-value` || !cookies[0].Secure {
+				t.Errorf(`%s: %s: unexpected cookies %v`, tt.name, st.FilePath(), cookies)
+			}
+			profiles = append(profiles, st.Profile())
+		}
+		if !slices.Equal(profiles, tt.want) {
+			t.Errorf(`%s: got profiles %q; want %q`, tt.name, profiles, tt.want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package whale
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+)
+
+func init() {
+	kooky.RegisterFinder(`whale`, &chrome.DerivativeFinder{Derivative: derivative})
+}
@@ -0,0 +1,10 @@
+package yandex
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome"
+)
+
+func init() {
+	kooky.RegisterFinder(`yandex`, &chrome.DerivativeFinder{Derivative: derivative})
+}
@@ -0,0 +1,10 @@
+package zen
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+)
+
+func init() {
+	kooky.RegisterFinder(`zen`, &firefox.ForkFinder{Fork: fork})
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/browser/lwp"
+	"github.com/browserutils/kooky/browser/netscape"
+	"github.com/browserutils/kooky/internal/utils"
+)
+
+// convertFile writes the cookies of a netscape cookies.txt or LWP file in another format.
+//
+// Netscape to LWP keeps all fields, including the SameSite column of libsoup.
+// The netscape format has no room for the LWP attributes port, version, discard and comment
+// and for SameSite (curl rejects an 8th column), those are lost when converting LWP to netscape.
+func convertFile(ctx context.Context, filename string, format, export *string) {
+	if len(filename) == 0 {
+		log.Fatalln(`usage: kooky convert --format <format> [-o <output file>] <cookie file>`)
+	}
+	f, typ, err := utils.DetectFileType(filename)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	f.Close()
+	var seq kooky.CookieSeq
+	switch typ {
+	case `lwp`:
+		seq = lwp.TraverseCookies(filename)
+	case `netscape`:
+		seq, _ = netscape.TraverseCookies(filename)
+	default:
+		log.Fatalf("%s: unsupported file type %q", filename, typ)
+	}
+
+	var w io.Writer = os.Stdout
+	if export != nil && len(*export) > 0 && *export != `-` {
+		fl, err := os.OpenFile(*export, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		defer fl.Close()
+		w = fl
+	}
+	if err := kooky.ExportCookiesFormat(ctx, w, kooky.Format(*format), seq); err != nil {
+		log.Fatalln(err)
+	}
+}
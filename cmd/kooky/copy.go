@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/browser/netscape"
+)
+
+// copyCookies copies cookies between browser profiles.
+// from and to are of the form "browser[:profile]", the default profile is used if the profile is omitted.
+// to might also be the path of a netscape cookies.txt file.
+func copyCookies(ctx context.Context, from, to, domain *string, dryRun *bool, opts []kooky.TraverseOption) {
+	if from == nil || to == nil || len(*from) == 0 || len(*to) == 0 {
+		log.Fatalln(`usage: kooky copy --from <browser[:profile]> --to <browser[:profile]|cookies.txt> [--domain <domain>]`)
+	}
+
+	srcStores := findStores(ctx, *from, opts)
+	if len(srcStores) == 0 {
+		log.Fatalf("no cookie store found for %q", *from)
+	}
+	defer closeStores(srcStores)
+
+	var dst kooky.CookieStore
+	if _, _, ok := strings.Cut(*to, `:`); !ok && strings.ContainsAny(*to, `/\.`) {
+		st, err := netscape.CookieStore(*to)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		dst = st
+	} else {
+		dstStores := findStores(ctx, *to, opts)
+		if len(dstStores) == 0 {
+			log.Fatalf("no cookie store found for %q", *to)
+		}
+		defer closeStores(dstStores)
+		// e.g. Firefox profiles have a read-only session store besides cookies.sqlite
+		dst = dstStores[0]
+		for _, st := range dstStores {
+			if kooky.CapabilitiesOf(st).Writable {
+				dst = st
+				break
+			}
+		}
+	}
+
+	filters := []kooky.Filter{kooky.Valid}
+	if domain != nil && len(*domain) > 0 {
+		filters = append(filters, domainOrSubdomain(*domain))
+	}
+	var srcSeqs []kooky.CookieSeq
+	for _, st := range srcStores {
+		srcSeqs = append(srcSeqs, st.TraverseCookies())
+	}
+	report, err := kooky.Sync(ctx, kooky.MergeCookieSeqs(srcSeqs...), dst, &kooky.SyncOptions{
+		Filters: filters,
+		DryRun:  dryRun != nil && *dryRun,
+	})
+	if report != nil {
+		for _, sk := range report.Skipped {
+			fmt.Fprintf(os.Stderr, "skipped %s %s%s: %s\n", sk.Cookie.Name, sk.Cookie.Domain, sk.Cookie.Path, sk.Reason)
+		}
+		for _, e := range report.Errors {
+			fmt.Fprintf(os.Stderr, "skipped: %v\n", e)
+		}
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	verb := `copied`
+	if dryRun != nil && *dryRun {
+		verb = `to be copied`
+	}
+	fmt.Fprintf(os.Stderr, "%d cookies %s to %s %q, %d skipped, %d errors\n", len(report.Written), verb, dst.Browser(), dst.FilePath(), len(report.Skipped), len(report.Errors))
+}
+
+// findStores returns the cookie stores of the profile described by spec ("browser[:profile]").
+func findStores(ctx context.Context, spec string, opts []kooky.TraverseOption) []kooky.CookieStore {
+	browser, profile, _ := strings.Cut(spec, `:`)
+	filter := kooky.StoreFilter{Browser: browser, Profile: profile, DefaultProfileOnly: len(profile) == 0}
+	opts = append(opts, kooky.OnlyBrowsers(browser))
+	return kooky.TraverseCookieStores(ctx, opts...).Filter(filter).AllCookieStores(ctx)
+}
+
+func closeStores(stores []kooky.CookieStore) {
+	for _, st := range stores {
+		st.Close()
+	}
+}
+
+// domainOrSubdomain matches cookies of the domain and its subdomains.
+func domainOrSubdomain(domain string) kooky.Filter {
+	domain = strings.TrimPrefix(domain, `.`)
+	return kooky.FilterFunc(func(c *kooky.Cookie) bool {
+		d := strings.TrimPrefix(c.Domain, `.`)
+		return d == domain || strings.HasSuffix(d, `.`+domain)
+	})
+}
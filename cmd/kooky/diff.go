@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/browserutils/kooky"
+)
+
+// diffInventories compares two JSON Lines cookie files written by "kooky -j".
+// The exit code is 1 if there are differences.
+func diffInventories(oldFile, newFile string, jsonFormat *bool) {
+	if len(oldFile) == 0 || len(newFile) == 0 {
+		log.Fatalln(`usage: kooky diff <old.jsonl> <new.jsonl>`)
+	}
+	oldCookies, err := readJSONLFile(oldFile)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	newCookies, err := readJSONLFile(newFile)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	changes := kooky.Diff(oldCookies, newCookies)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	for _, change := range changes {
+		if jsonFormat != nil && *jsonFormat {
+			b, err := json.Marshal(change)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Fprintf(w, "%s\n", b)
+			continue
+		}
+		prChangeLine(w, change)
+	}
+	w.Flush()
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
+
+func prChangeLine(w io.Writer, change kooky.CookieChange) {
+	var (
+		sign   string
+		cookie *kooky.Cookie
+	)
+	switch change.Type {
+	case kooky.CookieAdded:
+		sign, cookie = `+`, change.New
+	case kooky.CookieRemoved:
+		sign, cookie = `-`, change.Old
+	default:
+		sign, cookie = `~`, change.New
+	}
+	var details []string
+	for _, f := range change.Fields {
+		details = append(details, fmt.Sprintf(`%s: %q -> %q`, f.Field, f.Old, f.New))
+	}
+	container := cookie.Container
+	if len(container) > 0 {
+		container = ` [` + container + `]`
+	}
+	fmt.Fprintf(
+		w,
+		"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		sign,
+		prBrowser(cookie),
+		prProfile(cookie),
+		container,
+		cookie.Domain,
+		cookie.Path,
+		cookie.Name,
+		strings.Join(details, `; `),
+	)
+}
+
+func readJSONLFile(filename string) (kooky.Cookies, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cookies kooky.Cookies
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<24)
+	for line := 1; sc.Scan(); line++ {
+		if len(strings.TrimSpace(sc.Text())) == 0 {
+			continue
+		}
+		cookie := &kooky.Cookie{}
+		if err := json.Unmarshal(sc.Bytes(), cookie); err != nil {
+			return nil, fmt.Errorf(`%s:%d: %w`, filename, line, err)
+		}
+		cookies = append(cookies, cookie)
+	}
+	return cookies, sc.Err()
+}
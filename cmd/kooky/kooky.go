@@ -13,6 +13,8 @@ import (
 
 	"github.com/browserutils/kooky"
 	_ "github.com/browserutils/kooky/browser/all"
+	"github.com/browserutils/kooky/decode"
+	_ "github.com/browserutils/kooky/sqlitewrite" // kooky copy to Chromium and Firefox
 
 	"github.com/spf13/pflag"
 )
@@ -26,8 +28,55 @@ func main() {
 	name := pflag.StringP(`name`, `n`, ``, `cookie name filter (exact)`)
 	export := pflag.StringP(`export`, `o`, ``, `export cookies in netscape format`)
 	jsonFormat := pflag.BoolP(`jsonl`, `j`, false, `JSON Lines output format`)
+	parallel := pflag.Int(`parallel`, 0, `maximum number of cookie stores read in parallel (0: no limit)`)
+	storeTimeout := pflag.Duration(`store-timeout`, 0, `timeout for reading a single cookie store (0: none)`)
+	timeout := pflag.Duration(`timeout`, 0, `timeout for reading all cookie stores (0: none)`)
+	root := pflag.String(`root`, ``, `search below an alternate root directory (e.g. extracted Android app data)`)
+	sortKeys := pflag.String(`sort`, ``, `sort cookies by comma separated keys (default,browser,profile,domain,path,name,expiry)`)
+	from := pflag.String(`from`, ``, `copy: source browser[:profile]`)
+	to := pflag.String(`to`, ``, `copy: destination browser[:profile] or cookies.txt file`)
+	dryRun := pflag.Bool(`dry-run`, false, `copy: only report the cookies to be copied`)
+	decodeValues := pflag.Bool(`decode`, false, `decode cookie values (URL-encoding, base64, JWT, signed sessions)`)
+	format := pflag.String(`format`, string(kooky.FormatNetscape), `export format (netscape, lwp, playwright, puppeteer, selenium)`)
 	pflag.Parse()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	go func() { <-c; cancel() }()
+
+	opts := []kooky.TraverseOption{
+		kooky.MaxParallelStores(*parallel),
+		kooky.StoreTimeout(*storeTimeout),
+		kooky.TraverseTimeout(*timeout),
+	}
+	if len(*root) > 0 {
+		opts = append(opts, kooky.AlternateRoot(*root))
+	}
+
+	switch pflag.Arg(0) {
+	case ``:
+	case `profiles`:
+		listProfiles(ctx, browser, profile, defaultProfile, jsonFormat)
+		return
+	case `diff`:
+		diffInventories(pflag.Arg(1), pflag.Arg(2), jsonFormat)
+		return
+	case `copy`:
+		copyCookies(ctx, from, to, domain, dryRun, opts)
+		return
+	case `convert`:
+		convertFile(ctx, pflag.Arg(1), format, export)
+		return
+	case `export`:
+		// same as --export but defaulting to stdout
+		if len(*export) == 0 {
+			*export = `-`
+		}
+	default:
+		log.Fatalf("unknown command %q", pflag.Arg(0))
+	}
+
 	// cookie filters
 	filters := []kooky.Filter{storeFilter(browser, profile, defaultProfile)}
 	if showExpired == nil || !*showExpired {
@@ -40,26 +89,34 @@ func main() {
 		filters = append(filters, kooky.Name(*name))
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
-	go func() { <-c; cancel() }()
-
-	seq := kooky.TraverseCookies(ctx, filters...)
+	if browser != nil && len(*browser) > 0 {
+		// don't open cookie stores of other browsers
+		opts = append(opts, kooky.OnlyBrowsers(*browser))
+	}
+	seq := kooky.TraverseCookieStores(ctx, opts...).TraverseCookies(ctx, filters...)
+	if sortKeys != nil && len(*sortKeys) > 0 {
+		order, err := sortOrder(*sortKeys)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		seq = seq.Sorted(order)
+	}
 
 	if export != nil && len(*export) > 0 {
 		var f io.Writer // for netscape export
 		if *export == `-` {
 			f = os.Stdout
 		} else {
-			fl, err := os.OpenFile(*export, os.O_RDWR|os.O_CREATE, 0644)
+			fl, err := os.OpenFile(*export, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 			if err != nil {
 				log.Fatalln(err)
 			}
 			defer fl.Close()
 			f = fl
 		}
-		seq.Export(ctx, f)
+		if err := kooky.ExportCookiesFormat(ctx, f, kooky.Format(*format), seq); err != nil {
+			log.Fatalln(err)
+		}
 		return
 	}
 
@@ -70,21 +127,30 @@ func main() {
 	for cookie := range seq.Chan(ctx) {
 		if jsonFormat != nil && *jsonFormat {
 			b, err := json.Marshal(cookie)
+			if err == nil && *decodeValues {
+				b, err = withDecoded(b, decode.Cookie(cookie))
+			}
 			if err != nil {
 				log.Fatalln(err)
 			}
 			fmt.Fprintf(w, "%s\n", b)
 		} else {
-			prCookieLine(w, cookie, trimLen)
+			prCookieLine(w, cookie, trimLen, *decodeValues)
 		}
 	}
 	w.Flush()
 }
 
-func prCookieLine(w io.Writer, cookie *kooky.Cookie, trimLen int) {
+func prCookieLine(w io.Writer, cookie *kooky.Cookie, trimLen int, decodeValue bool) {
 	if cookie == nil {
 		return
 	}
+	// be careful about raw bytes
+	value := strings.Trim(fmt.Sprintf(`%q`, cookie.Value), `"`)
+	if decodeValue {
+		value = decode.Cookie(cookie).String()
+		trimLen = max(trimLen, 120)
+	}
 	container := cookie.Container
 	if len(container) > 0 {
 		container = ` [` + container + `]`
@@ -98,8 +164,7 @@ func prCookieLine(w io.Writer, cookie *kooky.Cookie, trimLen int) {
 		trimStr(prFilePath(cookie), trimLen),
 		trimStr(cookie.Domain, trimLen),
 		trimStr(cookie.Name, trimLen),
-		// be careful about raw bytes
-		trimStr(strings.Trim(fmt.Sprintf(`%q`, cookie.Value), `"`), trimLen),
+		trimStr(value, trimLen),
 		prExpires(cookie),
 	)
 }
@@ -133,22 +198,42 @@ func prFilePath(c *kooky.Cookie) string {
 }
 
 func storeFilter(browser, profile *string, defaultProfile *bool) kooky.Filter {
-	return kooky.FilterFunc(func(cookie *kooky.Cookie) bool {
-		if cookie == nil || cookie.Browser == nil {
-			return false
-		}
-		// cookie store filters
-		if browser != nil && len(*browser) > 0 && cookie.Browser.Browser() != *browser {
-			return false
-		}
-		if profile != nil && len(*profile) > 0 && cookie.Browser.Profile() != *profile {
-			return false
-		}
-		if defaultProfile != nil && *defaultProfile && !cookie.Browser.IsDefaultProfile() {
-			return false
+	// evaluated per cookie store before it is opened
+	var f kooky.StoreFilter
+	if browser != nil {
+		f.Browser = *browser
+	}
+	if profile != nil {
+		f.Profile = *profile
+	}
+	if defaultProfile != nil {
+		f.DefaultProfileOnly = *defaultProfile
+	}
+	return f
+}
+
+var sortCmps = map[string]kooky.CookieCmp{
+	`default`: kooky.DefaultSortOrder,
+	`browser`: kooky.ByBrowser,
+	`profile`: kooky.ByProfile,
+	`domain`:  kooky.ByDomain,
+	`path`:    kooky.ByPath,
+	`name`:    kooky.ByName,
+	`expiry`:  kooky.ByExpiry,
+}
+
+func sortOrder(keys string) (kooky.CookieCmp, error) {
+	var cmps []kooky.CookieCmp
+	for key := range strings.SplitSeq(keys, `,`) {
+		c, ok := sortCmps[strings.TrimSpace(key)]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort key %q", key)
 		}
-		return true
-	})
+		cmps = append(cmps, c)
+	}
+	// break remaining ties deterministically
+	cmps = append(cmps, kooky.DefaultSortOrder)
+	return kooky.SortOrder(cmps...), nil
 }
 
 func trimStr(str string, length int) string {
@@ -161,4 +246,14 @@ func trimStr(str string, length int) string {
 	return str[:length]
 }
 
+// withDecoded adds the decoded value to the JSON encoded cookie.
+func withDecoded(cookieJSON []byte, decoded *decode.Result) ([]byte, error) {
+	var m map[string]any
+	if err := json.Unmarshal(cookieJSON, &m); err != nil {
+		return nil, err
+	}
+	m[`decoded`] = decoded
+	return json.Marshal(m)
+}
+
 // TODO: "kooky -b firefox -o /dev/stdout | head" hangs
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/browserutils/kooky"
+)
+
+func listProfiles(ctx context.Context, browser, profile *string, defaultProfile, jsonFormat *bool) {
+	var opts []kooky.TraverseOption
+	if browser != nil && len(*browser) > 0 {
+		opts = append(opts, kooky.OnlyBrowsers(*browser))
+	}
+	var profiles []*kooky.Profile
+	for _, p := range kooky.FindProfiles(ctx, opts...) {
+		if profile != nil && len(*profile) > 0 && p.Name != *profile {
+			continue
+		}
+		if defaultProfile != nil && *defaultProfile && !p.IsDefault {
+			continue
+		}
+		profiles = append(profiles, p)
+	}
+	slices.SortFunc(profiles, func(a, b *kooky.Profile) int {
+		return cmp.Or(
+			cmp.Compare(a.Browser, b.Browser),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Dir, b.Dir),
+		)
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	for _, p := range profiles {
+		if jsonFormat != nil && *jsonFormat {
+			b, err := json.Marshal(p)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Fprintf(w, "%s\n", b)
+			continue
+		}
+		var def string
+		if p.IsDefault {
+			def = `*`
+		}
+		lastUsed := `-`
+		if !p.LastUsed.IsZero() {
+			lastUsed = p.LastUsed.Format(`2006.01.02 15:04:05`)
+		}
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			p.Browser,
+			p.Name,
+			def,
+			p.DisplayName,
+			p.AccountEmail,
+			lastUsed,
+			p.Dir,
+		)
+	}
+	w.Flush()
+}
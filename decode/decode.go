@@ -0,0 +1,360 @@
+// Package decode identifies and decodes common cookie value encodings.
+//
+// Signatures are not verified and encrypted values are not decrypted.
+package decode
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/browserutils/kooky"
+)
+
+// Kind is the detected encoding of a value.
+type Kind string
+
+const (
+	Plain          Kind = `plain`
+	URLEncoded     Kind = `url`
+	Base64         Kind = `base64`
+	Base64URL      Kind = `base64url`
+	JWT            Kind = `jwt`
+	Flask          Kind = `flask`  // Flask session / itsdangerous URLSafeTimedSerializer
+	Django         Kind = `django` // django.core.signing
+	Rails          Kind = `rails`  // ActiveSupport::MessageVerifier signed cookie
+	RailsEncrypted Kind = `rails-encrypted`
+	Opaque         Kind = `opaque` // e.g. Google NID
+)
+
+// Result is a decoded value.
+type Result struct {
+	Kind Kind `json:"kind"`
+	// Value is the decoded text if it isn't JSON.
+	Value string `json:"value,omitempty"`
+	// Payload is the decoded JSON (claims for JWTs).
+	Payload json.RawMessage `json:"payload,omitempty"`
+	// Header is the JWT header.
+	Header json.RawMessage `json:"header,omitempty"`
+	// Signed is the signing time of timestamped formats.
+	Signed time.Time `json:"signed,omitzero"`
+	// Expires is the expiry stated in the value (JWT "exp", Rails "exp").
+	Expires time.Time `json:"expires,omitzero"`
+	Notes   []string  `json:"notes,omitempty"`
+	// Inner is the decoded content of URL-encoded and base64 values.
+	Inner *Result `json:"inner,omitempty"`
+}
+
+// String returns a one line summary like "url > jwt {"alg":"HS256"} {"sub":"1"}".
+func (r *Result) String() string {
+	if r == nil {
+		return ``
+	}
+	var parts []string
+	for ; r != nil; r = r.Inner {
+		s := string(r.Kind)
+		if len(r.Header) > 0 {
+			s += ` ` + compact(r.Header)
+		}
+		if len(r.Payload) > 0 {
+			s += ` ` + compact(r.Payload)
+		} else if len(r.Value) > 0 && r.Inner == nil {
+			s += ` ` + fmt.Sprintf(`%q`, r.Value)
+		}
+		for _, n := range r.Notes {
+			s += ` (` + n + `)`
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, ` > `)
+}
+
+func compact(b json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		return string(b)
+	}
+	return buf.String()
+}
+
+// Cookie decodes the cookie value.
+// A JWT "exp" claim is compared with the cookie expiry.
+func Cookie(c *kooky.Cookie) *Result {
+	if c == nil {
+		return nil
+	}
+	r := decode(c.Name, c.Value, 0)
+	for in := r; in != nil; in = in.Inner {
+		if in.Kind != JWT || in.Expires.IsZero() {
+			continue
+		}
+		switch {
+		case in.Expires.Before(time.Now()):
+			in.Notes = append(in.Notes, `token expired`)
+		case !c.Expires.IsZero() && in.Expires.Before(c.Expires):
+			in.Notes = append(in.Notes, `token expires before cookie`)
+		case c.Expires.IsZero() || in.Expires.After(c.Expires):
+			in.Notes = append(in.Notes, `token outlives cookie`)
+		}
+	}
+	return r
+}
+
+// Value decodes a cookie value.
+func Value(v string) *Result { return decode(``, v, 0) }
+
+const maxDepth = 4
+
+var (
+	reNID        = regexp.MustCompile(`^\d{2,4}=[A-Za-z0-9_-]{20,}$`)
+	reHex        = regexp.MustCompile(`^[0-9a-f]{40}$|^[0-9a-f]{64}$`)
+	reBase62     = regexp.MustCompile(`^[0-9A-Za-z]{1,8}$`)
+	rePercentEnc = regexp.MustCompile(`%[0-9A-Fa-f]{2}`)
+)
+
+func decode(name, v string, depth int) *Result {
+	if depth > maxDepth || len(v) == 0 {
+		return &Result{Kind: Plain, Value: v}
+	}
+	if r := decodeJWT(v); r != nil {
+		return r
+	}
+	if r := decodeFlask(v); r != nil {
+		return r
+	}
+	if r := decodeDjango(v); r != nil {
+		return r
+	}
+	if r := decodeRails(v); r != nil {
+		return r
+	}
+	if name == `NID` || reNID.MatchString(v) {
+		return &Result{Kind: Opaque, Value: v}
+	}
+	if rePercentEnc.MatchString(v) {
+		if u, err := url.PathUnescape(v); err == nil && u != v {
+			return &Result{Kind: URLEncoded, Value: u, Inner: decode(name, u, depth+1)}
+		}
+	}
+	if kind, b, ok := decodeBase64(v); ok && len(v) >= 8 {
+		r := &Result{Kind: kind}
+		switch {
+		case json.Valid(b) && (b[0] == '{' || b[0] == '['):
+			r.Payload = b
+		case isText(b):
+			r.Value = string(b)
+			r.Inner = decode(name, string(b), depth+1)
+			if r.Inner.Kind == Plain {
+				r.Inner = nil
+			}
+		default:
+			return &Result{Kind: Plain, Value: v}
+		}
+		return r
+	}
+	return &Result{Kind: Plain, Value: v}
+}
+
+func isText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeBase64(v string) (Kind, []byte, bool) {
+	if strings.ContainsAny(v, `-_`) {
+		if b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(v, `=`)); err == nil && len(b) > 0 {
+			return Base64URL, b, true
+		}
+		return ``, nil, false
+	}
+	if b, err := base64.StdEncoding.DecodeString(v); err == nil && len(b) > 0 {
+		return Base64, b, true
+	}
+	if b, err := base64.RawStdEncoding.DecodeString(v); err == nil && len(b) > 0 {
+		return Base64, b, true
+	}
+	return ``, nil, false
+}
+
+// jsonSegment decodes a base64url JSON object.
+func jsonSegment(s string) (json.RawMessage, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, `=`))
+	if err != nil || len(b) == 0 || b[0] != '{' || !json.Valid(b) {
+		return nil, false
+	}
+	return b, true
+}
+
+func decodeJWT(v string) *Result {
+	parts := strings.Split(v, `.`)
+	if len(parts) != 3 {
+		return nil
+	}
+	header, ok := jsonSegment(parts[0])
+	if !ok {
+		return nil
+	}
+	var h struct {
+		Alg string `json:"alg"`
+	}
+	if json.Unmarshal(header, &h) != nil || len(h.Alg) == 0 {
+		return nil
+	}
+	r := &Result{Kind: JWT, Header: header}
+	if claims, ok := jsonSegment(parts[1]); ok {
+		r.Payload = claims
+		var c struct {
+			Exp json.Number `json:"exp"`
+		}
+		if json.Unmarshal(claims, &c) == nil {
+			if exp, err := c.Exp.Float64(); err == nil && exp > 0 {
+				r.Expires = time.Unix(int64(exp), 0)
+			}
+		}
+	} else {
+		r.Notes = append(r.Notes, `encrypted or non-JSON claims`)
+	}
+	return r
+}
+
+// itsdangerous: [.]payload.timestamp.signature, "." marks a zlib compressed payload
+func decodeFlask(v string) *Result {
+	compressed := strings.HasPrefix(v, `.`)
+	parts := strings.Split(strings.TrimPrefix(v, `.`), `.`)
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, ok := signedPayload(parts[0], compressed, base64.RawURLEncoding)
+	if !ok {
+		return nil
+	}
+	r := &Result{Kind: Flask, Payload: payload}
+	if ts, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil && len(ts) > 0 && len(ts) <= 8 {
+		var buf [8]byte
+		copy(buf[8-len(ts):], ts)
+		r.Signed = time.Unix(int64(binary.BigEndian.Uint64(buf[:])), 0)
+	}
+	return r
+}
+
+// django.core.signing: [.]payload:timestamp:signature
+func decodeDjango(v string) *Result {
+	parts := strings.Split(v, `:`)
+	if len(parts) != 3 || !reBase62.MatchString(parts[1]) || len(parts[2]) < 27 {
+		return nil
+	}
+	r := &Result{Kind: Django, Signed: time.Unix(base62(parts[1]), 0)}
+	if payload, ok := signedPayload(strings.TrimPrefix(parts[0], `.`), strings.HasPrefix(parts[0], `.`), base64.RawURLEncoding); ok {
+		r.Payload = payload
+	} else {
+		// HttpResponse.set_signed_cookie() with a plain value
+		r.Value = parts[0]
+	}
+	return r
+}
+
+// ActiveSupport::MessageVerifier: base64(data)--hexdigest
+// ActiveSupport::MessageEncryptor: base64(data)--base64(iv)--base64(tag)
+func decodeRails(v string) *Result {
+	// Rack escapes "+", "/" and "=" of the base64 as %XX, a literal "+" is base64 and no space
+	if u, err := url.PathUnescape(v); err == nil {
+		v = u
+	}
+	parts := strings.Split(v, `--`)
+	switch {
+	case len(parts) == 3:
+		for _, p := range parts {
+			if _, err := base64.StdEncoding.DecodeString(p); err != nil {
+				return nil
+			}
+		}
+		return &Result{Kind: RailsEncrypted, Notes: []string{`encrypted`}}
+	case len(parts) == 2 && reHex.MatchString(parts[1]):
+	default:
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil
+	}
+	r := &Result{Kind: Rails}
+	if !json.Valid(data) {
+		if bytes.HasPrefix(data, []byte{0x04, 0x08}) {
+			r.Notes = append(r.Notes, `ruby marshal`)
+		}
+		r.Value = string(data)
+		return r
+	}
+	r.Payload = data
+	// Rails 5.2+ metadata envelope
+	var env struct {
+		Rails struct {
+			Message string `json:"message"`
+			Exp     string `json:"exp"`
+			Pur     string `json:"pur"`
+		} `json:"_rails"`
+	}
+	if json.Unmarshal(data, &env) == nil && len(env.Rails.Message) > 0 {
+		if msg, err := base64.StdEncoding.DecodeString(env.Rails.Message); err == nil {
+			if json.Valid(msg) {
+				r.Payload = msg
+			} else {
+				r.Payload = nil
+				r.Value = string(msg)
+			}
+		}
+		if exp, err := time.Parse(time.RFC3339, env.Rails.Exp); err == nil {
+			r.Expires = exp
+		}
+		if len(env.Rails.Pur) > 0 {
+			r.Notes = append(r.Notes, `purpose `+env.Rails.Pur)
+		}
+	}
+	return r
+}
+
+func signedPayload(s string, compressed bool, enc *base64.Encoding) (json.RawMessage, bool) {
+	b, err := enc.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, false
+	}
+	if compressed {
+		zr, err := zlib.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, false
+		}
+		b, err = io.ReadAll(io.LimitReader(zr, 1<<20))
+		if err != nil {
+			return nil, false
+		}
+	}
+	if len(b) == 0 || (b[0] != '{' && b[0] != '[' && b[0] != '"') || !json.Valid(b) {
+		return nil, false
+	}
+	return b, true
+}
+
+func base62(s string) int64 {
+	const digits = `0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz`
+	var n int64
+	for _, c := range s {
+		n = n*62 + int64(strings.IndexRune(digits, c))
+	}
+	return n
+}
@@ -0,0 +1,90 @@
+package decode
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+)
+
+func TestValue(t *testing.T) {
+	b64url := base64.RawURLEncoding.EncodeToString
+	var zbuf bytes.Buffer
+	zw := zlib.NewWriter(&zbuf)
+	zw.Write([]byte(`{"_fresh":true}`))
+	zw.Close()
+	sig43 := strings.Repeat(`x`, 43)
+	railsEnvelope := base64.StdEncoding.EncodeToString([]byte(`{"_rails":{"message":"ImZvbyI=","exp":null,"pur":"cookie.remember"}}`))
+
+	tests := []struct {
+		value   string
+		kinds   string // Kind chain
+		payload string
+		val     string // innermost Value
+	}{
+		{value: `dark`, kinds: `plain`, val: `dark`},
+		{value: `a%20b%3Dc`, kinds: `url > plain`, val: `a b=c`},
+		{value: `aGVsbG8gd29ybGQ=`, kinds: `base64`, val: `hello world`},
+		{value: b64url([]byte(`{"k":"v"}`)), kinds: `base64`, payload: `{"k":"v"}`},
+		{value: b64url([]byte(`{"k":"??>"}`)), kinds: `base64url`, payload: `{"k":"??>"}`},
+		{value: `511=Aq7BdHk1Xl4gQm_pZ-uNoRt0sYw`, kinds: `opaque`},
+		{
+			value:   b64url([]byte(`{"alg":"HS256","typ":"JWT"}`)) + `.` + b64url([]byte(`{"sub":"1"}`)) + `.sig`,
+			kinds:   `jwt`,
+			payload: `{"sub":"1"}`,
+		},
+		{value: b64url([]byte(`{"user":1}`)) + `.ZjQ2Bg.` + sig43[:27], kinds: `flask`, payload: `{"user":1}`},
+		{value: `.` + b64url(zbuf.Bytes()) + `.ZjQ2Bg.` + sig43[:27], kinds: `flask`, payload: `{"_fresh":true}`},
+		{value: b64url([]byte(`{"a":1}`)) + `:1rGDsM:` + sig43, kinds: `django`, payload: `{"a":1}`},
+		{value: `hello:1rGDsM:` + sig43, kinds: `django`, val: `hello`},
+		{value: railsEnvelope + `--` + strings.Repeat(`a`, 40), kinds: `rails`, payload: `"foo"`},
+		{value: `YWJj--ZGVm--Z2hp`, kinds: `rails-encrypted`},
+		// "+" of the base64 unescaped and escaped
+		{value: `eyJrIjoiPj4+In0=--` + strings.Repeat(`a`, 40), kinds: `rails`, payload: `{"k":">>>"}`},
+		{value: `eyJrIjoiPj4%2BIn0%3D--` + strings.Repeat(`a`, 40), kinds: `rails`, payload: `{"k":">>>"}`},
+		{value: `eyJrIjoiPj4+In0%3D`, kinds: `url > base64`, payload: `{"k":">>>"}`},
+		{value: `eyJrIjoiPj4%2BIn0%3D`, kinds: `url > base64`, payload: `{"k":">>>"}`},
+	}
+	for _, tt := range tests {
+		r := Value(tt.value)
+		var kinds []string
+		in := r
+		for ; ; in = in.Inner {
+			kinds = append(kinds, string(in.Kind))
+			if in.Inner == nil {
+				break
+			}
+		}
+		if got := strings.Join(kinds, ` > `); got != tt.kinds {
+			t.Errorf("%q: got %s, want %s", tt.value, got, tt.kinds)
+			continue
+		}
+		if len(tt.payload) > 0 && string(in.Payload) != tt.payload {
+			t.Errorf("%q: got payload %s, want %s", tt.value, in.Payload, tt.payload)
+		}
+		if len(tt.val) > 0 && in.Value != tt.val && r.Value != tt.val {
+			t.Errorf("%q: got value %q, want %q", tt.value, in.Value, tt.val)
+		}
+	}
+}
+
+func TestCookieJWTExpiry(t *testing.T) {
+	b64url := base64.RawURLEncoding.EncodeToString
+	c := &kooky.Cookie{Cookie: http.Cookie{
+		Name:    `token`,
+		Value:   b64url([]byte(`{"alg":"none"}`)) + `.` + b64url([]byte(`{"exp":1}`)) + `.`,
+		Expires: time.Now().Add(time.Hour),
+	}}
+	r := Cookie(c)
+	if r.Kind != JWT || !r.Expires.Equal(time.Unix(1, 0)) {
+		t.Fatalf("got %s", r)
+	}
+	if len(r.Notes) != 1 || r.Notes[0] != `token expired` {
+		t.Errorf("got notes %q", r.Notes)
+	}
+}
@@ -0,0 +1,205 @@
+package kooky
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ChangeType is the kind of a CookieChange.
+type ChangeType int
+
+const (
+	CookieAdded ChangeType = iota + 1
+	CookieRemoved
+	CookieModified
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case CookieAdded:
+		return `added`
+	case CookieRemoved:
+		return `removed`
+	case CookieModified:
+		return `modified`
+	default:
+		return `unknown`
+	}
+}
+
+func (t ChangeType) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// CookieChange describes the difference of a cookie between two cookie inventories.
+//
+// Old is nil for added cookies, New is nil for removed cookies.
+type CookieChange struct {
+	Type   ChangeType    `json:"type"`
+	Old    *Cookie       `json:"old,omitempty"`
+	New    *Cookie       `json:"new,omitempty"`
+	Fields []FieldChange `json:"fields,omitempty"` // only for CookieModified
+}
+
+// FieldChange is a changed cookie field.
+// The field names are those of Cookie.MarshalJSON().
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// CookieKey identifies a cookie within a cookie inventory.
+type CookieKey struct {
+	Browser   string
+	Profile   string
+	Container string
+	Domain    string
+	Path      string
+	Name      string
+}
+
+func KeyOf(c *Cookie) CookieKey {
+	if c == nil {
+		return CookieKey{}
+	}
+	return CookieKey{
+		Browser:   browserOf(c),
+		Profile:   profileOf(c),
+		Container: c.Container,
+		Domain:    c.Domain,
+		Path:      c.Path,
+		Name:      c.Name,
+	}
+}
+
+func (k CookieKey) compare(o CookieKey) int {
+	return cmp.Or(
+		cmp.Compare(k.Browser, o.Browser),
+		cmp.Compare(k.Profile, o.Profile),
+		cmp.Compare(k.Container, o.Container),
+		cmp.Compare(k.Domain, o.Domain),
+		cmp.Compare(k.Path, o.Path),
+		cmp.Compare(k.Name, o.Name),
+	)
+}
+
+// Diff() compares two cookie inventories.
+//
+// Cookies are matched by their CookieKey.
+// Cookies with the same key within an inventory (e.g. from multiple cookie store files of a profile)
+// are paired up with unchanged cookies first.
+// The changes are sorted by key.
+func Diff(before, after Cookies) []CookieChange {
+	oldByKey := groupByKey(before)
+	newByKey := groupByKey(after)
+
+	keys := make([]CookieKey, 0, len(oldByKey)+len(newByKey))
+	for k := range oldByKey {
+		keys = append(keys, k)
+	}
+	for k := range newByKey {
+		if _, ok := oldByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.SortFunc(keys, CookieKey.compare)
+
+	var changes []CookieChange
+	for _, k := range keys {
+		olds, news := oldByKey[k], newByKey[k]
+
+		// drop unchanged pairs
+		olds = slices.DeleteFunc(olds, func(o *Cookie) bool {
+			i := slices.IndexFunc(news, func(n *Cookie) bool { return len(fieldChanges(o, n)) == 0 })
+			if i < 0 {
+				return false
+			}
+			news = slices.Delete(news, i, i+1)
+			return true
+		})
+
+		for i := 0; i < max(len(olds), len(news)); i++ {
+			switch {
+			case i >= len(news):
+				changes = append(changes, CookieChange{Type: CookieRemoved, Old: olds[i]})
+			case i >= len(olds):
+				changes = append(changes, CookieChange{Type: CookieAdded, New: news[i]})
+			default:
+				changes = append(changes, CookieChange{
+					Type:   CookieModified,
+					Old:    olds[i],
+					New:    news[i],
+					Fields: fieldChanges(olds[i], news[i]),
+				})
+			}
+		}
+	}
+	return changes
+}
+
+func groupByKey(cookies Cookies) map[CookieKey][]*Cookie {
+	ret := make(map[CookieKey][]*Cookie)
+	for _, c := range cookies {
+		if c == nil {
+			continue
+		}
+		k := KeyOf(c)
+		ret[k] = append(ret[k], c)
+	}
+	// deterministic pairing of cookies with the same key
+	for _, cs := range ret {
+		slices.SortStableFunc(cs, func(a, b *Cookie) int {
+			return cmp.Or(
+				cmp.Compare(filePathOf(a), filePathOf(b)),
+				cmp.Compare(a.Value, b.Value),
+			)
+		})
+	}
+	return ret
+}
+
+func fieldChanges(o, n *Cookie) []FieldChange {
+	var ret []FieldChange
+	add := func(field, oldVal, newVal string) {
+		if oldVal != newVal {
+			ret = append(ret, FieldChange{Field: field, Old: oldVal, New: newVal})
+		}
+	}
+	fmtBool := strconv.FormatBool
+	add(`value`, o.Value, n.Value)
+	add(`expires`, fmtTime(o.Expires), fmtTime(n.Expires))
+	add(`secure`, fmtBool(o.Secure), fmtBool(n.Secure))
+	add(`http_only`, fmtBool(o.HttpOnly), fmtBool(n.HttpOnly))
+	add(`partitioned`, fmtBool(o.Partitioned), fmtBool(n.Partitioned))
+	add(`quoted`, fmtBool(o.Quoted), fmtBool(n.Quoted))
+	add(`same_site`, fmtSameSite(o), fmtSameSite(n))
+	return ret
+}
+
+func fmtTime(t time.Time) string {
+	if t.IsZero() {
+		return ``
+	}
+	// don't report differing time zones
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func fmtSameSite(c *Cookie) string {
+	switch c.SameSite {
+	case 0:
+		return ``
+	case http.SameSiteDefaultMode:
+		return `default`
+	case http.SameSiteLaxMode:
+		return `lax`
+	case http.SameSiteStrictMode:
+		return `strict`
+	case http.SameSiteNoneMode:
+		return `none`
+	default:
+		return fmt.Sprint(int(c.SameSite))
+	}
+}
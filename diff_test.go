@@ -0,0 +1,107 @@
+package kooky
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	exp := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := Cookies{
+		{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `kept`, Value: `1`, Expires: exp}},
+		{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `changed`, Value: `a`, Expires: exp}},
+		{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `removed`, Value: `x`}},
+	}
+	new := Cookies{
+		{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `added`, Value: `y`}},
+		{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `changed`, Value: `b`, Expires: exp.Add(time.Hour), Secure: true, SameSite: http.SameSiteLaxMode}},
+		{Cookie: http.Cookie{Domain: `.example.com`, Path: `/`, Name: `kept`, Value: `1`, Expires: exp.In(time.FixedZone(`x`, 3600))}},
+	}
+
+	changes := Diff(old, new)
+	if len(changes) != 3 {
+		t.Fatalf("got %d changes, want 3: %+v", len(changes), changes)
+	}
+	// sorted by key: added, changed, removed
+	if changes[0].Type != CookieAdded || changes[0].New.Name != `added` {
+		t.Errorf("unexpected change %+v", changes[0])
+	}
+	if changes[1].Type != CookieModified || changes[1].Old.Name != `changed` {
+		t.Errorf("unexpected change %+v", changes[1])
+	}
+	fields := map[string]FieldChange{}
+	for _, f := range changes[1].Fields {
+		fields[f.Field] = f
+	}
+	for _, f := range []string{`value`, `expires`, `secure`, `same_site`} {
+		if _, ok := fields[f]; !ok {
+			t.Errorf("missing field change %q", f)
+		}
+	}
+	if len(fields) != 4 {
+		t.Errorf("got %d field changes, want 4: %+v", len(fields), changes[1].Fields)
+	}
+	if fields[`same_site`].New != `lax` {
+		t.Errorf("same_site=%q, want lax", fields[`same_site`].New)
+	}
+	if changes[2].Type != CookieRemoved || changes[2].Old.Name != `removed` {
+		t.Errorf("unexpected change %+v", changes[2])
+	}
+}
+
+func TestCookieJSONRoundTrip(t *testing.T) {
+	c := &Cookie{
+		Cookie: http.Cookie{
+			Domain:   `.example.com`,
+			Path:     `/`,
+			Name:     `n`,
+			Value:    `v`,
+			Expires:  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		},
+		Container: `Work`,
+		Browser:   &staticBrowserInfo{browser: `firefox`, profile: `default`, isDefaultProfile: true, filePath: `/p/cookies.sqlite`},
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c2 Cookie
+	if err := json.Unmarshal(b, &c2); err != nil {
+		t.Fatal(err)
+	}
+	if changes := Diff(Cookies{c}, Cookies{&c2}); len(changes) != 0 {
+		t.Errorf("round trip changed cookie: %+v", changes)
+	}
+	if c2.Browser.FilePath() != `/p/cookies.sqlite` || !c2.Browser.IsDefaultProfile() {
+		t.Errorf("browser info not restored: %+v", c2.Browser)
+	}
+
+	for _, want := range []time.Time{
+		time.Date(12345, 6, 7, 8, 9, 10, 0, time.UTC),
+		time.Date(12000, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(-1, 12, 31, 23, 59, 59, 5e8, time.UTC),
+		time.Date(10001, 3, 1, 0, 0, 0, 0, time.FixedZone(``, 2*3600)),
+	} {
+		b, err := json.Marshal(jsonTime{want})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tm jsonTime
+		if err := json.Unmarshal(b, &tm); err != nil {
+			t.Errorf("%s: %v", b, err)
+			continue
+		}
+		if !tm.Equal(want) {
+			t.Errorf("%s: got %v, want %v", b, tm.Time, want)
+		}
+	}
+	// no leap day in 10001
+	var tm jsonTime
+	if err := json.Unmarshal([]byte(`"10001-02-29T00:00:00Z"`), &tm); err == nil {
+		t.Errorf("no error for invalid date, got %v", tm.Time)
+	}
+}
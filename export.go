@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
+	"unicode"
 )
 
 const (
 	httpOnlyPrefix = `#HttpOnly_`
 	netscapeHeader = "# HTTP Cookie File\n\n"
+	lwpHeader      = "#LWP-Cookies-2.0\n"
 )
 
 // ExportCookies() export "cookies" in the Netscape format.
@@ -34,6 +38,11 @@ func exportCookie(w io.Writer, cookie *http.Cookie) {
 	}
 	domain += cookie.Domain
 
+	var expires int64 // 0 for session cookies
+	if !cookie.Expires.IsZero() {
+		expires = cookie.Expires.Unix()
+	}
+
 	fmt.Fprintf(
 		w,
 		"%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
@@ -41,7 +50,7 @@ func exportCookie(w io.Writer, cookie *http.Cookie) {
 		netscapeBool(strings.HasPrefix(cookie.Domain, `.`)),
 		cookie.Path,
 		netscapeBool(cookie.Secure),
-		cookie.Expires.Unix(),
+		expires,
 		cookie.Name,
 		cookie.Value,
 	)
@@ -104,6 +113,124 @@ func exportCookieSeq(ctx context.Context, w io.Writer, seq CookieSeq) {
 
 func (s CookieSeq) Export(ctx context.Context, w io.Writer) { exportCookieSeq(ctx, w, s) }
 
+// ExportCookiesLWP() exports cookies in the format of Python's http.cookiejar.LWPCookieJar
+// ("Set-Cookie3" lines).
+//
+// Attributes without a Cookie field (version, port, discard, ...) are taken from http.Cookie.Unparsed
+// where the LWP reader stores them. HttpOnly and SameSite are kept as nonstandard attributes
+// like http.cookiejar does.
+func ExportCookiesLWP(ctx context.Context, w io.Writer, cookies CookieSeq) {
+	fmt.Fprint(w, lwpHeader)
+	for cookie := range cookies.OnlyCookies() {
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Fprintf(w, "Set-Cookie3: %s\n", lwpCookieString(cookie))
+	}
+}
+
+// lwpCookieString is the equivalent of http.cookiejar.lwp_cookie_str().
+func lwpCookieString(c *Cookie) string {
+	rest := make(map[string]*string)
+	for _, attr := range c.Unparsed {
+		k, v, ok := strings.Cut(attr, `=`)
+		if ok {
+			rest[k] = &v
+		} else {
+			rest[k] = nil
+		}
+	}
+	// LWP files always have a version, other sources get the attributes derived
+	_, fromLWP := rest[`version`]
+	take := func(k string) (*string, bool) {
+		v, ok := rest[k]
+		delete(rest, k)
+		return v, ok
+	}
+
+	type pair struct {
+		k string
+		v *string
+	}
+	str := func(s string) *string { return &s }
+	h := []pair{{c.Name, str(c.Value)}, {`path`, str(c.Path)}, {`domain`, str(c.Domain)}}
+	if port, ok := take(`port`); ok {
+		h = append(h, pair{`port`, port})
+	}
+	if _, ok := take(`path_spec`); ok || !fromLWP {
+		h = append(h, pair{`path_spec`, nil})
+	}
+	if _, ok := take(`port_spec`); ok {
+		h = append(h, pair{`port_spec`, nil})
+	}
+	if _, ok := take(`domain_dot`); ok || (!fromLWP && strings.HasPrefix(c.Domain, `.`)) {
+		h = append(h, pair{`domain_dot`, nil})
+	}
+	if c.Secure {
+		h = append(h, pair{`secure`, nil})
+	}
+	if !c.Expires.IsZero() {
+		h = append(h, pair{`expires`, str(c.Expires.UTC().Format(lwpTimeFormat))})
+	}
+	if _, ok := take(`discard`); ok || (!fromLWP && c.Expires.IsZero()) {
+		h = append(h, pair{`discard`, nil})
+	}
+	for _, k := range []string{`comment`, `commenturl`} {
+		if v, ok := take(k); ok {
+			h = append(h, pair{k, v})
+		}
+	}
+	version, ok := take(`version`)
+	if !ok || version == nil {
+		version = str(`0`)
+	}
+	if c.HttpOnly {
+		rest[`HttpOnly`] = str(`None`)
+	}
+	switch c.SameSite {
+	case http.SameSiteNoneMode:
+		rest[`SameSite`] = str(`None`)
+	case http.SameSiteLaxMode:
+		rest[`SameSite`] = str(`Lax`)
+	case http.SameSiteStrictMode:
+		rest[`SameSite`] = str(`Strict`)
+	}
+	for _, k := range slices.Sorted(maps.Keys(rest)) {
+		h = append(h, pair{k, rest[k]})
+	}
+	h = append(h, pair{`version`, version})
+
+	parts := make([]string, 0, len(h))
+	for _, p := range h {
+		if p.v == nil {
+			parts = append(parts, p.k)
+			continue
+		}
+		parts = append(parts, p.k+`=`+lwpQuote(*p.v))
+	}
+	return strings.Join(parts, `; `)
+}
+
+// time2isoz() of http.cookiejar
+const lwpTimeFormat = `2006-01-02 15:04:05Z`
+
+// lwpQuote quotes values which aren't word characters only like http.cookiejar.join_header_words().
+func lwpQuote(v string) string {
+	word := len(v) > 0
+	for _, r := range v {
+		if !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			word = false
+			break
+		}
+	}
+	if word {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	return `"` + v + `"`
+}
+
 type netscapeBool bool
 
 func (b netscapeBool) String() string {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
@@ -174,6 +175,88 @@ func FilterCookie[T Cookie | http.Cookie](ctx context.Context, cookie *T, filter
 	return true
 }
 
+// cookie store filters
+
+// BrowserInfoFilter is a Filter which only depends on the BrowserInfo of a cookie.
+//
+// CookieStoreSeq.TraverseCookies() evaluates those filters once per cookie store
+// and skips non-matching cookie stores without opening them.
+type BrowserInfoFilter interface {
+	Filter
+	FilterBrowserInfo(BrowserInfo) bool
+}
+
+type BrowserInfoFilterFunc func(BrowserInfo) bool
+
+func (f BrowserInfoFilterFunc) Filter(c *Cookie) bool {
+	return f != nil && c != nil && c.Browser != nil && f(c.Browser)
+}
+
+func (f BrowserInfoFilterFunc) FilterBrowserInfo(b BrowserInfo) bool {
+	return f != nil && b != nil && f(b)
+}
+
+var _ BrowserInfoFilter = BrowserInfoFilterFunc(nil)
+
+// StoreFilter selects cookie stores. Empty fields don't restrict the selection.
+type StoreFilter struct {
+	Browser            string
+	Profile            string
+	DefaultProfileOnly bool
+	FilePathGlob       string // pattern syntax of filepath.Match()
+}
+
+var _ BrowserInfoFilter = StoreFilter{}
+
+func (f StoreFilter) Filter(c *Cookie) bool {
+	return c != nil && f.FilterBrowserInfo(c.Browser)
+}
+
+func (f StoreFilter) FilterBrowserInfo(b BrowserInfo) bool {
+	if b == nil {
+		return false
+	}
+	if len(f.Browser) > 0 && b.Browser() != f.Browser {
+		return false
+	}
+	if len(f.Profile) > 0 && b.Profile() != f.Profile {
+		return false
+	}
+	if f.DefaultProfileOnly && !b.IsDefaultProfile() {
+		return false
+	}
+	if len(f.FilePathGlob) > 0 {
+		if ok, err := filepath.Match(f.FilePathGlob, b.FilePath()); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// splitStoreFilters separates the filters that can be evaluated per cookie store.
+func splitStoreFilters(filters []Filter) (storeFilters []BrowserInfoFilter, cookieFilters []Filter) {
+	for _, filter := range filters {
+		if sf, ok := filter.(BrowserInfoFilter); ok {
+			storeFilters = append(storeFilters, sf)
+		} else {
+			cookieFilters = append(cookieFilters, filter)
+		}
+	}
+	return storeFilters, cookieFilters
+}
+
+func filterBrowserInfo(b BrowserInfo, filters ...BrowserInfoFilter) bool {
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		if !filter.FilterBrowserInfo(b) {
+			return false
+		}
+	}
+	return true
+}
+
 // debug filter
 
 // Debug prints the cookie.
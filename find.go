@@ -2,9 +2,11 @@ package kooky
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"net/http"
+	"slices"
 	"sync"
 )
 
@@ -19,6 +21,56 @@ type CookieStore interface {
 	Close() error
 }
 
+// CookieWriter is implemented by cookie stores which can store cookies.
+//
+// Stored cookies replace those with the same domain, path and name.
+// Cookies with a negative MaxAge or an expiry date in the past are deleted.
+type CookieWriter interface {
+	WriteCookies(...*Cookie) error
+}
+
+// StoreCapabilities are the cookie properties a cookie store is able to store.
+type StoreCapabilities struct {
+	// Writable is set if WriteCookies() is supported.
+	Writable bool
+	// Containers is set if Cookie.Container (Firefox containers) is stored.
+	Containers bool
+	// Partitions is set if partitioned (CHIPS) cookies are stored.
+	Partitions bool
+	// PersistentOnly is set if session cookies can't be stored.
+	PersistentOnly bool
+}
+
+// CapabilityReporter is implemented by cookie stores reporting what they are able to store.
+// Cookie stores wrapping others report the capabilities of the wrapped one.
+type CapabilityReporter interface {
+	Capabilities() StoreCapabilities
+}
+
+// CapabilitiesOf() returns what the cookie store is able to store.
+// Cookie stores without a CapabilityReporter implementation
+// are writable if they implement CookieWriter.
+func CapabilitiesOf(store any) StoreCapabilities {
+	if r, ok := store.(CapabilityReporter); ok {
+		return r.Capabilities()
+	}
+	_, ok := store.(CookieWriter)
+	return StoreCapabilities{Writable: ok}
+}
+
+// PersistentCookieStore is a CookieStore which can write cookies received
+// via http.CookieJar.SetCookies() back, similar to curl's "--cookie-jar" option.
+type PersistentCookieStore interface {
+	CookieStore
+	// Persist() enables the write-back.
+	//
+	// If sidecar is empty, cookies are written to the cookie store itself,
+	// which is only possible if it implements CookieWriter.
+	// Otherwise they are written to the netscape cookies.txt file sidecar.
+	// The cookies already stored in the sidecar file are loaded into the jar.
+	Persist(sidecar string) error
+}
+
 type BrowserInfo interface {
 	Browser() string
 	Profile() string
@@ -31,6 +83,13 @@ type CookieStoreFinder interface {
 	FindCookieStores() CookieStoreSeq
 }
 
+// RootFinder is implemented by CookieStoreFinders that are able to search
+// below an alternate root directory instead of the default locations,
+// e.g. a mounted disk or app data extracted from a phone.
+type RootFinder interface {
+	FindCookieStoresIn(root string) CookieStoreSeq
+}
+
 var (
 	finders  = map[string]CookieStoreFinder{}
 	muFinder sync.RWMutex
@@ -48,6 +107,25 @@ func RegisterFinder(browser string, finder CookieStoreFinder) {
 	}
 }
 
+// RegisteredBrowsers() returns the sorted names of the registered CookieStoreFinders.
+func RegisteredBrowsers() []string {
+	muFinder.RLock()
+	defer muFinder.RUnlock()
+	browsers := make([]string, 0, len(finders))
+	for browser := range finders {
+		browsers = append(browsers, browser)
+	}
+	slices.Sort(browsers)
+	return browsers
+}
+
+// Finder() returns the CookieStoreFinder registered for browser or nil.
+func Finder(browser string) CookieStoreFinder {
+	muFinder.RLock()
+	defer muFinder.RUnlock()
+	return finders[browser]
+}
+
 // FindAllCookieStores() tries to find cookie stores at default locations.
 //
 // FindAllCookieStores() requires registered CookieStoreFinders.
@@ -82,6 +160,24 @@ func (s CookieStoreSeq) OnlyCookieStores() CookieStoreSeq {
 	}
 }
 
+// Filter() skips cookie stores not passing the filters.
+func (s CookieStoreSeq) Filter(filters ...BrowserInfoFilter) CookieStoreSeq {
+	return func(yield func(CookieStore, error) bool) {
+		if s == nil {
+			return
+		}
+		for cookieStore, err := range s {
+			if err == nil && cookieStore != nil && !filterBrowserInfo(cookieStore, filters...) {
+				cookieStore.Close()
+				continue
+			}
+			if !yield(cookieStore, err) {
+				return
+			}
+		}
+	}
+}
+
 func (s CookieStoreSeq) AllCookieStores(ctx context.Context) []CookieStore {
 	var ret []CookieStore
 	if s == nil {
@@ -102,10 +198,15 @@ Outer:
 	return ret
 }
 
+// TraverseCookies() reads the cookies of all cookie stores in the sequence.
+//
+// BrowserInfoFilters (e.g. StoreFilter) among the filters are evaluated once per cookie store.
+// Cookie stores not passing them are skipped without being read.
 func (s CookieStoreSeq) TraverseCookies(ctx context.Context, filters ...Filter) CookieSeq {
 	if s == nil {
 		return func(yield func(*Cookie, error) bool) {}
 	}
+	storeFilters, filters := splitStoreFilters(filters)
 
 	ctx, cancel := context.WithCancel(ctx)
 	type ce struct {
@@ -138,6 +239,10 @@ func (s CookieStoreSeq) TraverseCookies(ctx context.Context, filters ...Filter)
 			if cookieStore == nil {
 				continue
 			}
+			if !filterBrowserInfo(cookieStore, storeFilters...) {
+				cookieStore.Close()
+				continue
+			}
 			wg.Add(1)
 			go func(cookieStore CookieStore) {
 				defer wg.Done()
@@ -172,66 +277,56 @@ func (s CookieStoreSeq) TraverseCookies(ctx context.Context, filters ...Filter)
 	}
 }
 
-func TraverseCookieStores(ctx context.Context) CookieStoreSeq {
-	ctx, cancel := context.WithCancel(ctx)
-	type se struct {
-		s CookieStore
-		e error
-	}
-	startChan := make(chan struct{}, 1)
-	storeChan := make(chan se, 1)
-
-	go func() {
-		select {
-		case <-ctx.Done():
-			return
-		case <-startChan:
-		}
-
-		muFinder.RLock()
-		defer muFinder.RUnlock()
-
-		var wg sync.WaitGroup
-		defer func() {
-			wg.Wait()
-			close(storeChan)
-		}()
+// TraverseCookieStores() traverses the cookie stores found by the registered CookieStoreFinders.
+//
+// The traversal can be configured with options like OnlyBrowsers() or StoreTimeout().
+func TraverseCookieStores(ctx context.Context, opts ...TraverseOption) CookieStoreSeq {
+	cfg := newTraverseConfig(opts...)
 
-		// TODO: use wg.Go when switching to Go 1.25
-		for _, finder := range finders {
-			if finder == nil {
+	muFinder.RLock()
+	var seqs []iter.Seq2[CookieStore, error]
+	for _, finder := range cfg.selectedFinders() {
+		find := finder.FindCookieStores
+		if len(cfg.root) > 0 {
+			rf, ok := finder.(RootFinder)
+			if !ok {
 				continue
 			}
-			wg.Add(1)
-			go func(finder CookieStoreFinder) {
-				defer wg.Done()
-				for cookieStore, err := range finder.FindCookieStores() {
-					select {
-					case <-ctx.Done():
-						return
-					case storeChan <- se{s: cookieStore, e: err}:
-					}
-				}
-			}(finder)
+			find = func() CookieStoreSeq { return rf.FindCookieStoresIn(cfg.root) }
 		}
-	}()
+		seqs = append(seqs, func(yield func(CookieStore, error) bool) {
+			for cookieStore, err := range find() {
+				if !yield(cookieStore, err) {
+					return
+				}
+			}
+		})
+	}
+	muFinder.RUnlock()
 
 	return func(yield func(CookieStore, error) bool) {
-		startChan <- struct{}{}
-		for {
-			select {
-			case <-ctx.Done():
+		callerCtx := ctx
+		ctx, cancel := cfg.withDeadline(ctx)
+		defer cancel()
+		deadline, _ := ctx.Deadline()
+		// cookie stores which are not yielded because the search ended early are closed
+		closeStore := func(cookieStore CookieStore) {
+			if cookieStore != nil {
+				cookieStore.Close()
+			}
+		}
+		for cookieStore, err := range mergeSeqs(ctx, cfg.maxParallel, closeStore, seqs...) {
+			if err == nil && cookieStore != nil {
+				// the cookie stores may be read after the search ended,
+				// they don't inherit its cancellation
+				cookieStore = cfg.limitCookieStore(callerCtx, deadline, cookieStore)
+			}
+			if !yield(cookieStore, err) {
 				return
-			case s, ok := <-storeChan:
-				if !ok {
-					cancel()
-					return
-				}
-				if !yield(s.s, s.e) {
-					cancel()
-					return
-				}
 			}
 		}
+		if err := ctx.Err(); errors.Is(err, context.DeadlineExceeded) {
+			_ = yield(nil, fmt.Errorf(`cookie store search: %w`, err))
+		}
 	}
 }
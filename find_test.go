@@ -0,0 +1,335 @@
+package kooky_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+type countingFinder struct{ calls int }
+
+func (f *countingFinder) FindCookieStores() kooky.CookieStoreSeq {
+	f.calls++
+	return func(yield func(kooky.CookieStore, error) bool) {}
+}
+
+func TestOnlyBrowsers(t *testing.T) {
+	wanted, unwanted := &countingFinder{}, &countingFinder{}
+	kooky.RegisterFinder(`onlybrowsers-wanted`, wanted)
+	kooky.RegisterFinder(`onlybrowsers-unwanted`, unwanted)
+
+	browsers := kooky.RegisteredBrowsers()
+	for _, b := range []string{`onlybrowsers-wanted`, `onlybrowsers-unwanted`} {
+		if !slices.Contains(browsers, b) {
+			t.Errorf("%q not in RegisteredBrowsers()", b)
+		}
+	}
+	if !slices.IsSorted(browsers) {
+		t.Error(`RegisteredBrowsers() is not sorted`)
+	}
+	if kooky.Finder(`onlybrowsers-wanted`) != wanted {
+		t.Error(`Finder() returned wrong finder`)
+	}
+	if kooky.Finder(`onlybrowsers-nonexistent`) != nil {
+		t.Error(`Finder() returned finder for unregistered browser`)
+	}
+
+	ctx := context.Background()
+	_ = kooky.TraverseCookieStores(ctx, kooky.OnlyBrowsers(`onlybrowsers-wanted`)).AllCookieStores(ctx)
+	if wanted.calls != 1 {
+		t.Errorf("selected finder called %d times, want 1", wanted.calls)
+	}
+	if unwanted.calls != 0 {
+		t.Errorf("unselected finder called %d times, want 0", unwanted.calls)
+	}
+}
+
+type rootCountingFinder struct {
+	countingFinder
+	roots []string
+}
+
+func (f *rootCountingFinder) FindCookieStoresIn(root string) kooky.CookieStoreSeq {
+	f.roots = append(f.roots, root)
+	return func(yield func(kooky.CookieStore, error) bool) {}
+}
+
+func TestAlternateRoot(t *testing.T) {
+	rooted, unrooted := &rootCountingFinder{}, &countingFinder{}
+	kooky.RegisterFinder(`alternateroot-rooted`, rooted)
+	kooky.RegisterFinder(`alternateroot-unrooted`, unrooted)
+
+	ctx := context.Background()
+	opts := []kooky.TraverseOption{
+		kooky.OnlyBrowsers(`alternateroot-rooted`, `alternateroot-unrooted`),
+		kooky.AlternateRoot(`/mnt/phone`),
+	}
+	_ = kooky.TraverseCookieStores(ctx, opts...).AllCookieStores(ctx)
+	if !slices.Equal(rooted.roots, []string{`/mnt/phone`}) {
+		t.Errorf("RootFinder searched roots %q, want [/mnt/phone]", rooted.roots)
+	}
+	if rooted.calls != 0 || unrooted.calls != 0 {
+		t.Errorf("default locations searched %d and %d times, want 0", rooted.calls, unrooted.calls)
+	}
+}
+
+type traverseCountingStore struct {
+	cookies.DefaultCookieStore
+	traversals int
+}
+
+func (s *traverseCountingStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	s.traversals++
+	return func(yield func(*kooky.Cookie, error) bool) {
+		yield(&kooky.Cookie{Cookie: http.Cookie{Name: `n`}, Browser: s}, nil)
+	}
+}
+
+func TestStoreFilter(t *testing.T) {
+	stores := []*traverseCountingStore{
+		{DefaultCookieStore: cookies.DefaultCookieStore{BrowserStr: `a`, ProfileStr: `p1`, IsDefaultProfileBool: true, FileNameStr: `/a/p1/cookies`}},
+		{DefaultCookieStore: cookies.DefaultCookieStore{BrowserStr: `a`, ProfileStr: `p2`, FileNameStr: `/a/p2/cookies`}},
+		{DefaultCookieStore: cookies.DefaultCookieStore{BrowserStr: `b`, ProfileStr: `p1`, IsDefaultProfileBool: true, FileNameStr: `/b/p1/cookies`}},
+	}
+	seq := func(yield func(kooky.CookieStore, error) bool) {
+		for _, st := range stores {
+			if !yield(&cookies.CookieJar{CookieStore: st}, nil) {
+				return
+			}
+		}
+	}
+
+	tests := []struct {
+		filter kooky.Filter
+		want   []int // traversals per store
+	}{
+		{kooky.StoreFilter{Browser: `a`}, []int{1, 1, 0}},
+		{kooky.StoreFilter{Profile: `p1`}, []int{1, 0, 1}},
+		{kooky.StoreFilter{DefaultProfileOnly: true, Browser: `b`}, []int{0, 0, 1}},
+		{kooky.StoreFilter{FilePathGlob: `/a/*/cookies`}, []int{1, 1, 0}},
+		{kooky.BrowserInfoFilterFunc(func(b kooky.BrowserInfo) bool { return b.Profile() == `p2` }), []int{0, 1, 0}},
+	}
+	ctx := context.Background()
+	for i, tt := range tests {
+		for _, st := range stores {
+			st.traversals = 0
+		}
+		var n int
+		for range kooky.CookieStoreSeq(seq).TraverseCookies(ctx, tt.filter).OnlyCookies() {
+			n++
+		}
+		var wantN int
+		for j, st := range stores {
+			if st.traversals != tt.want[j] {
+				t.Errorf("test %d: store %d traversed %d times, want %d", i, j, st.traversals, tt.want[j])
+			}
+			wantN += tt.want[j]
+		}
+		if n != wantN {
+			t.Errorf("test %d: got %d cookies, want %d", i, n, wantN)
+		}
+	}
+}
+
+type hangingStore struct {
+	cookies.DefaultCookieStore
+	hang chan struct{}
+}
+
+func (s *hangingStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	return func(yield func(*kooky.Cookie, error) bool) {
+		if s.hang != nil {
+			<-s.hang
+		}
+		yield(&kooky.Cookie{Cookie: http.Cookie{Name: s.ProfileStr}, Browser: s}, nil)
+	}
+}
+
+type hangingFinder struct{ hang chan struct{} }
+
+func (f *hangingFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for _, st := range []*hangingStore{
+			{DefaultCookieStore: cookies.DefaultCookieStore{BrowserStr: `hangtest`, ProfileStr: `hanging`}, hang: f.hang},
+			{DefaultCookieStore: cookies.DefaultCookieStore{BrowserStr: `hangtest`, ProfileStr: `ok`}},
+		} {
+			if !yield(&cookies.CookieJar{CookieStore: st}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestStoreTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+	kooky.RegisterFinder(`hangtest`, &hangingFinder{hang: hang})
+
+	ctx := context.Background()
+	var (
+		names    []string
+		timeouts int
+	)
+	seq := kooky.TraverseCookieStores(
+		ctx,
+		kooky.OnlyBrowsers(`hangtest`),
+		kooky.MaxParallelStores(1),
+		kooky.StoreTimeout(50*time.Millisecond),
+		kooky.TraverseTimeout(5*time.Second),
+	).TraverseCookies(ctx)
+	for cookie, err := range seq {
+		if err != nil {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			timeouts++
+			continue
+		}
+		names = append(names, cookie.Name)
+	}
+	if timeouts != 1 {
+		t.Errorf("got %d timeout errors, want 1", timeouts)
+	}
+	if !slices.Equal(names, []string{`ok`}) {
+		t.Errorf("got cookies %v, want [ok]", names)
+	}
+}
+
+type slowConsumerFinder struct{}
+
+func (f *slowConsumerFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		st := &multiCookieStore{DefaultCookieStore: cookies.DefaultCookieStore{BrowserStr: `slowconsumer`}, n: 3}
+		yield(&cookies.CookieJar{CookieStore: st}, nil)
+	}
+}
+
+type multiCookieStore struct {
+	cookies.DefaultCookieStore
+	n int
+}
+
+func (s *multiCookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	return func(yield func(*kooky.Cookie, error) bool) {
+		for range s.n {
+			if !yield(&kooky.Cookie{Browser: s}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestStoreTimeoutExcludesConsumer(t *testing.T) {
+	kooky.RegisterFinder(`slowconsumer`, &slowConsumerFinder{})
+
+	ctx := context.Background()
+	var n int
+	for store := range kooky.TraverseCookieStores(ctx, kooky.OnlyBrowsers(`slowconsumer`), kooky.StoreTimeout(30*time.Millisecond)).OnlyCookieStores() {
+		if _, ok := store.(kooky.PersistentCookieStore); !ok {
+			t.Error(`limited cookie store doesn't forward Persist()`)
+		}
+		for _, err := range store.TraverseCookies() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			n++
+			time.Sleep(20 * time.Millisecond) // the consumer time doesn't count
+		}
+	}
+	if n != 3 {
+		t.Errorf("got %d cookies, want 3", n)
+	}
+}
+
+func TestTraverseCancel(t *testing.T) {
+	kooky.RegisterFinder(`slowconsumer`, &slowConsumerFinder{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var stores []kooky.CookieStore
+	for store := range kooky.TraverseCookieStores(ctx, kooky.OnlyBrowsers(`slowconsumer`), kooky.TraverseTimeout(5*time.Second)).OnlyCookieStores() {
+		stores = append(stores, store)
+	}
+	if len(stores) != 1 {
+		t.Fatalf("got %d cookie stores, want 1", len(stores))
+	}
+	// readable after the search ended
+	if cookies, err := stores[0].TraverseCookies().ReadAllCookies(ctx); err != nil || len(cookies) != 3 {
+		t.Errorf("got %d cookies and error %v, want 3 cookies", len(cookies), err)
+	}
+	cancel()
+	if _, err := stores[0].TraverseCookies().ReadAllCookies(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v after cancellation, want %v", err, context.Canceled)
+	}
+}
+
+type closeCountingStore struct {
+	cookies.DefaultCookieStore
+	closed atomic.Bool
+}
+
+func (s *closeCountingStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	return func(yield func(*kooky.Cookie, error) bool) {}
+}
+
+func (s *closeCountingStore) Close() error {
+	s.closed.Store(true)
+	return nil
+}
+
+type closeCountingFinder struct{ stores []*closeCountingStore }
+
+func (f *closeCountingFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for _, st := range f.stores {
+			if !yield(&cookies.CookieJar{CookieStore: st}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestTraverseTimeoutClosesStores(t *testing.T) {
+	f := &closeCountingFinder{}
+	for range 3 {
+		f.stores = append(f.stores, &closeCountingStore{DefaultCookieStore: cookies.DefaultCookieStore{BrowserStr: `closetest`}})
+	}
+	kooky.RegisterFinder(`closetest`, f)
+
+	ctx := context.Background()
+	var yielded int
+	for store, err := range kooky.TraverseCookieStores(ctx, kooky.OnlyBrowsers(`closetest`), kooky.TraverseTimeout(30*time.Millisecond)) {
+		if err != nil {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			continue
+		}
+		yielded++
+		if store == nil {
+			t.Fatal(`nil cookie store`)
+		}
+		// the others are buffered or sent while the timeout fires
+		time.Sleep(60 * time.Millisecond)
+	}
+	if yielded != 1 {
+		t.Fatalf("got %d cookie stores, want 1", yielded)
+	}
+	// unyielded stores are closed in the background
+	deadline := time.Now().Add(time.Second)
+	for !f.stores[1].closed.Load() || !f.stores[2].closed.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal(`cookie stores not yielded after the timeout weren't closed`)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if f.stores[0].closed.Load() {
+		t.Error(`yielded cookie store closed by the traversal`)
+	}
+}
@@ -0,0 +1,161 @@
+// Package android locates the data directories of Android apps
+// on a device or in app data extracted from one.
+package android
+
+import (
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// App is the data directory of an installed app.
+type App struct {
+	Package string // package name, e.g. "com.android.chrome"
+	Dir     string
+	User    string // Android user ID, empty for the primary user
+	backup  bool   // "apps/<package>/" layout of an unpacked adb backup
+}
+
+// Path() returns the path of a file or directory relative to the app data directory
+// ("/data/data/<package>/<pathParts>").
+//
+// adb backups split the app data into the domains "f" (files), "db" (databases),
+// "sp" (shared_prefs), "c" (cache) and "r" (everything else).
+func (a *App) Path(pathParts ...string) string {
+	if a == nil {
+		return ``
+	}
+	if !a.backup || len(pathParts) == 0 {
+		return filepath.Join(append([]string{a.Dir}, pathParts...)...)
+	}
+	var domain []string
+	switch pathParts[0] {
+	case `files`:
+		domain = []string{`f`}
+	case `databases`:
+		domain = []string{`db`}
+	case `shared_prefs`:
+		domain = []string{`sp`}
+	case `cache`:
+		domain = []string{`c`}
+	default:
+		domain = []string{`r`, pathParts[0]}
+	}
+	return filepath.Join(append(append([]string{a.Dir}, domain...), pathParts[1:]...)...)
+}
+
+// Apps() yields the apps found below root. If packages are passed, only those are yielded.
+//
+// The following layouts are recognized:
+//   - a copy of the device root ("<root>/data/data/<package>", "<root>/data/user/<id>/<package>")
+//   - an unpacked adb backup ("<root>/apps/<package>")
+//   - a copy of /data/data ("<root>/<package>")
+//   - a single app data directory ("<root>" named after the package, if none of the above)
+func Apps(root string, packages ...string) iter.Seq[*App] {
+	return func(yield func(*App) bool) {
+		wanted := func(pkg string) bool {
+			return len(packages) == 0 || slices.Contains(packages, pkg)
+		}
+		yieldDir := func(dir, user string, backup bool) bool {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				return true
+			}
+			for _, e := range entries {
+				if !e.IsDir() || !isPackageName(e.Name()) || !wanted(e.Name()) {
+					continue
+				}
+				app := &App{Package: e.Name(), Dir: filepath.Join(dir, e.Name()), User: user, backup: backup}
+				if !yield(app) {
+					return false
+				}
+			}
+			return true
+		}
+
+		var found bool
+		dataDir := filepath.Join(root, `data`, `data`)
+		if isDir(dataDir) {
+			found = true
+			if !yieldDir(dataDir, ``, false) {
+				return
+			}
+		}
+		if users, err := os.ReadDir(filepath.Join(root, `data`, `user`)); err == nil {
+			found = true
+			for _, u := range users {
+				// /data/user/0 is the same as /data/data
+				if !u.IsDir() || (u.Name() == `0` && isDir(dataDir)) {
+					continue
+				}
+				user := u.Name()
+				if user == `0` {
+					user = ``
+				}
+				if !yieldDir(filepath.Join(root, `data`, `user`, u.Name()), user, false) {
+					return
+				}
+			}
+		}
+		if isDir(filepath.Join(root, `apps`)) {
+			found = true
+			if !yieldDir(filepath.Join(root, `apps`), ``, true) {
+				return
+			}
+		}
+		if found {
+			return
+		}
+		// a root named like a package ("~/phone.dump") is only an app data directory
+		// if it doesn't contain app data directories itself
+		if pkg := filepath.Base(root); isPackageName(pkg) && !hasPackageDirs(root) {
+			if wanted(pkg) {
+				_ = yield(&App{Package: pkg, Dir: root})
+			}
+			return
+		}
+		_ = yieldDir(root, ``, false)
+	}
+}
+
+// hasPackageDirs reports whether dir has subdirectories named like packages.
+func hasPackageDirs(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if e.IsDir() && isPackageName(e.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDir(dir string) bool {
+	fi, err := os.Stat(dir)
+	return err == nil && fi.IsDir()
+}
+
+// isPackageName() reports whether name has the form of a Java package name like "org.mozilla.firefox".
+func isPackageName(name string) bool {
+	if !strings.Contains(name, `.`) {
+		return false
+	}
+	for part := range strings.SplitSeq(name, `.`) {
+		if len(part) == 0 {
+			return false
+		}
+		for i, r := range part {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			case r >= '0' && r <= '9' && i > 0:
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,313 @@
+package bidi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox"
+	"github.com/browserutils/kooky/internal/iterx"
+)
+
+// cookie is the BiDi network.Cookie type.
+type cookie struct {
+	Name     string     `json:"name"`
+	Value    bytesValue `json:"value"`
+	Domain   string     `json:"domain"`
+	Path     string     `json:"path,omitempty"`
+	HTTPOnly bool       `json:"httpOnly"`
+	Secure   bool       `json:"secure"`
+	SameSite string     `json:"sameSite,omitempty"` // strict, lax, none or default
+	Expiry   int64      `json:"expiry,omitempty"`   // seconds since epoch, missing for session cookies
+	Size     int        `json:"size,omitempty"`
+}
+
+// bytesValue is the BiDi network.BytesValue type.
+type bytesValue struct {
+	Type  string `json:"type"` // string or base64
+	Value string `json:"value"`
+}
+
+// partitionDescriptor is the BiDi storage.StorageKeyPartitionDescriptor type.
+// userContext is the container ("default" for none) and sourceOrigin the top-level site of partitioned cookies.
+type partitionDescriptor struct {
+	Type         string `json:"type"` // storageKey
+	UserContext  string `json:"userContext,omitempty"`
+	SourceOrigin string `json:"sourceOrigin,omitempty"`
+}
+
+const defaultUserContext = `default`
+
+func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	if s == nil {
+		return iterx.ErrCookieSeq(errors.New(`cookie store is nil`))
+	}
+	if err := s.Open(); err != nil {
+		return iterx.ErrCookieSeq(err)
+	}
+	return func(yield func(*kooky.Cookie, error) bool) {
+		ctx := context.Background()
+		for _, userContext := range s.userContexts() {
+			// storage.getCookies without sourceOrigin returns the unpartitioned cookies only,
+			// partitioned cookies are queried per top-level site
+			seen := make(map[string]struct{})
+			origins := s.topLevelOrigins(userContext)
+			for i := 0; i <= len(origins); i++ {
+				var sourceOrigin string
+				if i > 0 {
+					sourceOrigin = origins[i-1]
+					if _, ok := seen[sourceOrigin]; ok {
+						continue
+					}
+				}
+				seen[sourceOrigin] = struct{}{}
+				var result struct {
+					Cookies      []cookie            `json:"cookies"`
+					PartitionKey partitionDescriptor `json:"partitionKey"`
+				}
+				params := map[string]any{`partition`: partitionDescriptor{Type: `storageKey`, UserContext: userContext, SourceOrigin: sourceOrigin}}
+				if err := s.call(`storage.getCookies`, params, &result); err != nil {
+					if !yield(nil, err) {
+						return
+					}
+					continue
+				}
+				if len(result.PartitionKey.SourceOrigin) == 0 {
+					result.PartitionKey.SourceOrigin = sourceOrigin
+				}
+				for _, c := range result.Cookies {
+					if len(sourceOrigin) == 0 {
+						// the sites of unpartitioned cookies are candidates for partition keys
+						origins = append(origins, siteOrigin(c.Domain))
+					}
+					k, err := s.kookyCookie(c, userContext, result.PartitionKey.SourceOrigin)
+					if !iterx.CookieFilterYield(ctx, k, err, yield, filters...) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// userContexts returns the IDs of the user contexts, "default" first,
+// and maps them to container names.
+func (s *CookieStore) userContexts() []string {
+	userContexts := []string{defaultUserContext}
+	var uc struct {
+		UserContexts []struct {
+			UserContext string `json:"userContext"`
+		} `json:"userContexts"`
+	}
+	if err := s.call(`browser.getUserContexts`, nil, &uc); err == nil && len(uc.UserContexts) > 0 {
+		userContexts = userContexts[:0]
+		for _, u := range uc.UserContexts {
+			userContexts = append(userContexts, u.UserContext)
+		}
+	}
+	s.initContainerNames(userContexts)
+	return userContexts
+}
+
+// initContainerNames maps user context IDs to Firefox container names.
+//
+// BiDi user context IDs are random. Firefox registers the public containers of containers.json
+// in the order of the file on startup and appends new ones, so they are matched by position.
+// The IDs are kept if the profile isn't accessible (remote browser) or the numbers differ.
+func (s *CookieStore) initContainerNames(userContexts []string) {
+	s.containerNames = nil
+	if len(s.client.profileDir) == 0 {
+		return
+	}
+	names, err := firefox.PublicContainerNames(s.client.profileDir)
+	if err != nil {
+		return
+	}
+	var ids []string
+	for _, id := range userContexts {
+		if id != defaultUserContext {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) != len(names) {
+		return
+	}
+	s.containerNames = make(map[string]string, len(ids))
+	for i, id := range ids {
+		if len(names[i]) > 0 {
+			s.containerNames[id] = names[i]
+		}
+	}
+}
+
+// userContextOf returns the user context ID of the container name.
+func (s *CookieStore) userContextOf(container string) string {
+	for id, name := range s.containerNames {
+		if name == container {
+			return id
+		}
+	}
+	return container
+}
+
+// topLevelOrigins returns the origins of the top-level browsing contexts of the user context.
+func (s *CookieStore) topLevelOrigins(userContext string) []string {
+	var tree struct {
+		Contexts []struct {
+			URL         string `json:"url"`
+			UserContext string `json:"userContext"`
+		} `json:"contexts"`
+	}
+	if err := s.call(`browsingContext.getTree`, map[string]any{`maxDepth`: 0}, &tree); err != nil {
+		return nil
+	}
+	var origins []string
+	for _, c := range tree.Contexts {
+		if c.UserContext != userContext {
+			continue
+		}
+		u, err := url.Parse(c.URL)
+		if err != nil || (u.Scheme != `http` && u.Scheme != `https`) {
+			continue
+		}
+		origins = append(origins, siteOrigin(u.Hostname()))
+	}
+	return origins
+}
+
+// siteOrigin returns the https origin of the site (registrable domain) of the host.
+func siteOrigin(host string) string {
+	host = strings.TrimPrefix(host, `.`)
+	if site, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		host = site
+	}
+	return `https://` + host
+}
+
+func (s *CookieStore) kookyCookie(c cookie, userContext, sourceOrigin string) (*kooky.Cookie, error) {
+	k := &kooky.Cookie{Browser: s}
+	k.Name = c.Name
+	switch c.Value.Type {
+	case `base64`:
+		v, err := base64.StdEncoding.DecodeString(c.Value.Value)
+		if err != nil {
+			return nil, err
+		}
+		k.Value = string(v)
+	default:
+		k.Value = c.Value.Value
+	}
+	k.Domain = c.Domain
+	k.Path = c.Path
+	k.HttpOnly = c.HTTPOnly
+	k.Secure = c.Secure
+	if c.Expiry > 0 {
+		k.Expires = time.Unix(c.Expiry, 0)
+	}
+	switch c.SameSite {
+	case `strict`:
+		k.SameSite = http.SameSiteStrictMode
+	case `lax`:
+		k.SameSite = http.SameSiteLaxMode
+	case `none`:
+		k.SameSite = http.SameSiteNoneMode
+	}
+	if userContext != defaultUserContext {
+		k.Container = userContext
+		if name, ok := s.containerNames[userContext]; ok {
+			k.Container = name
+		}
+	}
+	if len(sourceOrigin) > 0 {
+		k.Partitioned = true
+		k.PartitionKey = sourceOrigin
+	}
+	return k, nil
+}
+
+func (s *CookieStore) partitionOf(k *kooky.Cookie) partitionDescriptor {
+	p := partitionDescriptor{Type: `storageKey`}
+	if len(k.Container) > 0 {
+		p.UserContext = s.userContextOf(k.Container)
+	}
+	if k.Partitioned {
+		p.SourceOrigin = k.PartitionKey
+	}
+	return p
+}
+
+var (
+	_ kooky.CookieWriter       = (*CookieStore)(nil)
+	_ kooky.CapabilityReporter = (*CookieStore)(nil)
+)
+
+func (s *CookieStore) Capabilities() kooky.StoreCapabilities {
+	return kooky.StoreCapabilities{Writable: true, Containers: true, Partitions: true}
+}
+
+// WriteCookies sets the cookies with storage.setCookie.
+// Expired cookies are deleted with storage.deleteCookies.
+// Cookie.Container is the container name or the user context ID.
+// A leading dot of Cookie.Domain makes a domain cookie, host-only cookies have none.
+func (s *CookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	if err := s.Open(); err != nil {
+		return err
+	}
+	if s.containerNames == nil {
+		s.userContexts()
+	}
+	now := time.Now()
+	for _, k := range kookies {
+		if k == nil {
+			continue
+		}
+		if k.MaxAge < 0 || (!k.Expires.IsZero() && k.Expires.Before(now)) {
+			params := map[string]any{
+				`filter`: map[string]any{
+					`name`:   k.Name,
+					`domain`: k.Domain,
+					`path`:   k.Path,
+				},
+				`partition`: s.partitionOf(k),
+			}
+			if err := s.call(`storage.deleteCookies`, params, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		c := cookie{
+			Name:     k.Name,
+			Value:    bytesValue{Type: `string`, Value: k.Value},
+			Domain:   k.Domain,
+			Path:     k.Path,
+			HTTPOnly: k.HttpOnly,
+			Secure:   k.Secure,
+		}
+		if !k.Expires.IsZero() {
+			c.Expiry = k.Expires.Unix()
+		}
+		switch k.SameSite {
+		case http.SameSiteStrictMode:
+			c.SameSite = `strict`
+		case http.SameSiteLaxMode:
+			c.SameSite = `lax`
+		case http.SameSiteNoneMode:
+			c.SameSite = `none`
+		}
+		params := map[string]any{`cookie`: c, `partition`: s.partitionOf(k)}
+		if err := s.call(`storage.setCookie`, params, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
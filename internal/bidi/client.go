@@ -0,0 +1,82 @@
+package bidi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/browserutils/kooky/internal/wsrpc"
+)
+
+// https://w3c.github.io/webdriver-bidi/
+
+// Client is a minimal WebDriver BiDi client.
+type Client struct {
+	*wsrpc.Client
+	sessionID  string
+	profileDir string // Firefox profile directory ("moz:profile" capability)
+}
+
+// Dial connects to the WebDriver BiDi endpoint and starts a session.
+//
+// endpoint is either the WebSocket URL ("ws://127.0.0.1:9222/session")
+// or the address of the remote debugging port ("127.0.0.1:9222").
+//
+// Firefox rejects WebSocket connections with an Origin header unless started with
+// "--remote-allow-origins", e.g. "--remote-allow-origins=http://127.0.0.1:9222".
+func Dial(ctx context.Context, endpoint string) (*Client, error) {
+	wsURL := endpoint
+	if !strings.HasPrefix(wsURL, `ws://`) && !strings.HasPrefix(wsURL, `wss://`) {
+		wsURL = strings.TrimPrefix(strings.TrimPrefix(wsURL, `http://`), `https://`)
+		wsURL = `ws://` + strings.TrimSuffix(wsURL, `/`) + `/session`
+	}
+	conn, err := wsrpc.Dial(ctx, wsURL, replyError)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{Client: conn}
+	var session struct {
+		SessionID    string `json:"sessionId"`
+		Capabilities struct {
+			MozProfile string `json:"moz:profile"`
+		} `json:"capabilities"`
+	}
+	if err := c.Call(ctx, `session.new`, map[string]any{`capabilities`: map[string]any{}}, &session); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c.sessionID = session.SessionID
+	c.profileDir = session.Capabilities.MozProfile
+	return c, nil
+}
+
+// Error is an error returned by a BiDi command.
+type Error struct {
+	Code    string `json:"error"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string { return fmt.Sprintf(`webdriver bidi error %q: %s`, e.Code, e.Message) }
+
+func replyError(reply []byte) error {
+	e := &Error{}
+	if err := json.Unmarshal(reply, e); err != nil {
+		return err
+	}
+	return e
+}
+
+// Close ends the session and closes the connection.
+func (c *Client) Close() error {
+	if c == nil || c.Client == nil {
+		return nil
+	}
+	if len(c.sessionID) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_ = c.Call(ctx, `session.end`, nil, nil)
+		cancel()
+	}
+	return c.Client.Close()
+}
@@ -0,0 +1,57 @@
+package bidi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+// timeout limits connecting and each command,
+// an unresponsive browser would block forever otherwise.
+const timeout = 30 * time.Second
+
+type CookieStore struct {
+	cookies.DefaultCookieStore // FileNameStr is the WebDriver BiDi endpoint
+	client                     *Client
+	containerNames             map[string]string // user context ID → Firefox container name
+}
+
+var _ cookies.CookieStore = (*CookieStore)(nil)
+
+func (s *CookieStore) Open() error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	if s.client != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	client, err := Dial(ctx, s.FileNameStr)
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+func (s *CookieStore) Close() error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Close()
+	s.client = nil
+	s.containerNames = nil
+	return err
+}
+
+func (s *CookieStore) call(method string, params, result any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return s.client.Call(ctx, method, params, result)
+}
@@ -0,0 +1,247 @@
+package cdp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/iterx"
+)
+
+// cookie is the DevTools Network.Cookie type.
+type cookie struct {
+	Name         string        `json:"name"`
+	Value        string        `json:"value"`
+	Domain       string        `json:"domain"`
+	Path         string        `json:"path"`
+	Expires      float64       `json:"expires"` // seconds since epoch, -1 for session cookies
+	HTTPOnly     bool          `json:"httpOnly"`
+	Secure       bool          `json:"secure"`
+	Session      bool          `json:"session"`
+	SameSite     string        `json:"sameSite,omitempty"`
+	Priority     string        `json:"priority,omitempty"`
+	SameParty    bool          `json:"sameParty,omitempty"`
+	SourceScheme string        `json:"sourceScheme,omitempty"`
+	PartitionKey *partitionKey `json:"partitionKey,omitempty"`
+}
+
+// cookieParam is the DevTools Network.CookieParam type.
+type cookieParam struct {
+	Name         string        `json:"name"`
+	Value        string        `json:"value"`
+	URL          string        `json:"url,omitempty"`
+	Domain       string        `json:"domain,omitempty"`
+	Path         string        `json:"path,omitempty"`
+	Secure       bool          `json:"secure,omitempty"`
+	HTTPOnly     bool          `json:"httpOnly,omitempty"`
+	SameSite     string        `json:"sameSite,omitempty"`
+	Expires      float64       `json:"expires,omitempty"`
+	Priority     string        `json:"priority,omitempty"`
+	SameParty    bool          `json:"sameParty,omitempty"`
+	SourceScheme string        `json:"sourceScheme,omitempty"`
+	PartitionKey *partitionKey `json:"partitionKey,omitempty"`
+}
+
+// partitionKey is the DevTools Network.CookiePartitionKey type.
+// Chrome versions before 128 use the top-level site string instead.
+type partitionKey struct {
+	TopLevelSite         string `json:"topLevelSite"`
+	HasCrossSiteAncestor bool   `json:"hasCrossSiteAncestor"`
+	asString             bool   // form of Chrome versions before 128
+}
+
+func (k *partitionKey) UnmarshalJSON(b []byte) error {
+	var site string
+	if err := json.Unmarshal(b, &site); err == nil {
+		k.TopLevelSite = site
+		k.asString = true
+		return nil
+	}
+	type plain partitionKey
+	return json.Unmarshal(b, (*plain)(k))
+}
+
+func (k partitionKey) MarshalJSON() ([]byte, error) {
+	if k.asString {
+		return json.Marshal(k.TopLevelSite)
+	}
+	type plain partitionKey
+	return json.Marshal(plain(k))
+}
+
+// firstObjectPartitionKeyVersion is the first Chrome version with Network.CookiePartitionKey objects.
+const firstObjectPartitionKeyVersion = 128
+
+// names of the attributes stored in http.Cookie.Unparsed
+const (
+	attrPriority             = `Priority`
+	attrSameParty            = `SameParty`
+	attrSourceScheme         = `SourceScheme`
+	attrHasCrossSiteAncestor = `HasCrossSiteAncestor` // "true" or "false", only for partitioned cookies with the object form
+)
+
+func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	if s == nil {
+		return iterx.ErrCookieSeq(errors.New(`cookie store is nil`))
+	}
+	if err := s.Open(); err != nil {
+		return iterx.ErrCookieSeq(err)
+	}
+	return func(yield func(*kooky.Cookie, error) bool) {
+		var result struct {
+			Cookies []cookie `json:"cookies"`
+		}
+		if err := s.call(`Storage.getCookies`, nil, &result); err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, c := range result.Cookies {
+			if !iterx.CookieFilterYield(context.Background(), s.kookyCookie(c), nil, yield, filters...) {
+				return
+			}
+		}
+	}
+}
+
+func (s *CookieStore) kookyCookie(c cookie) *kooky.Cookie {
+	k := &kooky.Cookie{Browser: s}
+	k.Name = c.Name
+	k.Value = c.Value
+	k.Domain = c.Domain
+	k.Path = c.Path
+	k.HttpOnly = c.HTTPOnly
+	k.Secure = c.Secure
+	if !c.Session && c.Expires > 0 {
+		sec, frac := math.Modf(c.Expires)
+		k.Expires = time.Unix(int64(sec), int64(frac*1e9))
+	}
+	switch c.SameSite {
+	case `Strict`:
+		k.SameSite = http.SameSiteStrictMode
+	case `Lax`:
+		k.SameSite = http.SameSiteLaxMode
+	case `None`:
+		k.SameSite = http.SameSiteNoneMode
+	}
+	if c.PartitionKey != nil && len(c.PartitionKey.TopLevelSite) > 0 {
+		k.Partitioned = true
+		k.PartitionKey = c.PartitionKey.TopLevelSite
+		if !c.PartitionKey.asString {
+			k.Unparsed = append(k.Unparsed, attrHasCrossSiteAncestor+`=`+strconv.FormatBool(c.PartitionKey.HasCrossSiteAncestor))
+		}
+	}
+	// no http.Cookie fields for these
+	if len(c.Priority) > 0 {
+		k.Unparsed = append(k.Unparsed, attrPriority+`=`+c.Priority)
+	}
+	if c.SameParty {
+		k.Unparsed = append(k.Unparsed, attrSameParty)
+	}
+	if len(c.SourceScheme) > 0 {
+		k.Unparsed = append(k.Unparsed, attrSourceScheme+`=`+c.SourceScheme)
+	}
+	return k
+}
+
+// toCookieParam converts the cookie, partition keys are sent as string if stringPartitionKey is set.
+func toCookieParam(k *kooky.Cookie, stringPartitionKey bool) cookieParam {
+	p := cookieParam{
+		Name:     k.Name,
+		Value:    k.Value,
+		Domain:   k.Domain,
+		Path:     k.Path,
+		Secure:   k.Secure,
+		HTTPOnly: k.HttpOnly,
+	}
+	if len(p.Path) == 0 {
+		p.Path = `/`
+	}
+	if !strings.HasPrefix(p.Domain, `.`) {
+		// a domain parameter sets a domain cookie, host-only cookies need the URL instead
+		scheme := `http`
+		if p.Secure {
+			scheme = `https`
+		}
+		p.URL = scheme + `://` + p.Domain + p.Path
+		p.Domain = ``
+	}
+	if !k.Expires.IsZero() {
+		p.Expires = float64(k.Expires.UnixNano()) / 1e9
+	}
+	switch k.SameSite {
+	case http.SameSiteStrictMode:
+		p.SameSite = `Strict`
+	case http.SameSiteLaxMode:
+		p.SameSite = `Lax`
+	case http.SameSiteNoneMode:
+		p.SameSite = `None`
+	}
+	if k.Partitioned && len(k.PartitionKey) > 0 {
+		p.PartitionKey = &partitionKey{TopLevelSite: k.PartitionKey, asString: stringPartitionKey}
+	}
+	for _, attr := range k.Unparsed {
+		name, val, _ := strings.Cut(attr, `=`)
+		switch name {
+		case attrPriority:
+			p.Priority = val
+		case attrSameParty:
+			p.SameParty = true
+		case attrSourceScheme:
+			p.SourceScheme = val
+		case attrHasCrossSiteAncestor:
+			if p.PartitionKey != nil {
+				p.PartitionKey.HasCrossSiteAncestor, _ = strconv.ParseBool(val)
+			}
+		}
+	}
+	return p
+}
+
+var (
+	_ kooky.CookieWriter       = (*CookieStore)(nil)
+	_ kooky.CapabilityReporter = (*CookieStore)(nil)
+)
+
+func (s *CookieStore) Capabilities() kooky.StoreCapabilities {
+	return kooky.StoreCapabilities{Writable: true, Partitions: true}
+}
+
+// WriteCookies sets the cookies with Storage.setCookies.
+// Expired cookies are deleted by overwriting them with an expired cookie,
+// the browser target has no Network.deleteCookies.
+func (s *CookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	if err := s.Open(); err != nil {
+		return err
+	}
+	version := s.majorVersion()
+	stringPartitionKey := version > 0 && version < firstObjectPartitionKeyVersion
+	now := time.Now()
+	var set []cookieParam
+	for _, k := range kookies {
+		if k == nil {
+			continue
+		}
+		p := toCookieParam(k, stringPartitionKey)
+		if k.MaxAge < 0 || (!k.Expires.IsZero() && k.Expires.Before(now)) {
+			p.Value = ``
+			p.Expires = expiredTime
+		}
+		set = append(set, p)
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	return s.call(`Storage.setCookies`, map[string]any{`cookies`: set}, nil)
+}
+
+// expiredTime is 1970-01-01 00:00:01 UTC, 0 would mean a session cookie
+const expiredTime = 1
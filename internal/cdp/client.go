@@ -0,0 +1,85 @@
+package cdp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/browserutils/kooky/internal/wsrpc"
+)
+
+// https://chromedevtools.github.io/devtools-protocol/
+
+// Dial connects to the browser target.
+//
+// endpoint is either the WebSocket URL of the browser target ("ws://127.0.0.1:9222/devtools/browser/<id>")
+// or the HTTP address of the remote debugging port ("http://127.0.0.1:9222", "127.0.0.1:9222")
+// which is used to look up the WebSocket URL.
+//
+// Chrome rejects WebSocket connections with an Origin header unless started with
+// "--remote-allow-origins", e.g. "--remote-allow-origins=http://127.0.0.1:9222".
+//
+// Only the browser wide domains (Browser, Storage, Target, ...) are available on the browser target,
+// Network methods are not.
+func Dial(ctx context.Context, endpoint string) (*wsrpc.Client, error) {
+	wsURL, err := webSocketURL(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return wsrpc.Dial(ctx, wsURL, replyError)
+}
+
+func webSocketURL(ctx context.Context, endpoint string) (string, error) {
+	if strings.HasPrefix(endpoint, `ws://`) || strings.HasPrefix(endpoint, `wss://`) {
+		return endpoint, nil
+	}
+	if !strings.Contains(endpoint, `://`) {
+		endpoint = `http://` + endpoint
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, `/`)+`/json/version`, nil)
+	if err != nil {
+		return ``, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return ``, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ``, fmt.Errorf(`devtools version info: %s`, resp.Status)
+	}
+	var version struct {
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return ``, err
+	}
+	if len(version.WebSocketDebuggerURL) == 0 {
+		return ``, errors.New(`devtools version info without WebSocket URL`)
+	}
+	return version.WebSocketDebuggerURL, nil
+}
+
+// Error is an error returned by a DevTools method.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string { return fmt.Sprintf(`devtools error %d: %s`, e.Code, e.Message) }
+
+func replyError(reply []byte) error {
+	var r struct {
+		Error *Error `json:"error"`
+	}
+	if err := json.Unmarshal(reply, &r); err != nil {
+		return err
+	}
+	if r.Error == nil {
+		return errors.New(`malformed error reply`)
+	}
+	return r.Error
+}
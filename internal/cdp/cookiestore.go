@@ -0,0 +1,77 @@
+package cdp
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/wsrpc"
+)
+
+// timeout limits connecting and each method call,
+// an unresponsive browser would block forever otherwise.
+const timeout = 30 * time.Second
+
+type CookieStore struct {
+	cookies.DefaultCookieStore // FileNameStr is the DevTools endpoint
+	client                     *wsrpc.Client
+	version                    int // major browser version, 0 if not yet known
+}
+
+var _ cookies.CookieStore = (*CookieStore)(nil)
+
+func (s *CookieStore) Open() error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	if s.client != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	client, err := Dial(ctx, s.FileNameStr)
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+func (s *CookieStore) Close() error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Close()
+	s.client = nil
+	s.version = 0
+	return err
+}
+
+func (s *CookieStore) call(method string, params, result any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return s.client.Call(ctx, method, params, result)
+}
+
+// majorVersion returns the major version of the connected browser or 0 if it's unknown.
+func (s *CookieStore) majorVersion() int {
+	if s.version > 0 {
+		return s.version
+	}
+	var result struct {
+		Product string `json:"product"` // e.g. "Chrome/130.0.6723.58" or "HeadlessChrome/127.0.6533.88"
+	}
+	if err := s.call(`Browser.getVersion`, nil, &result); err != nil {
+		return 0
+	}
+	_, version, _ := strings.Cut(result.Product, `/`)
+	major, _, _ := strings.Cut(version, `.`)
+	s.version, _ = strconv.Atoi(major)
+	return s.version
+}
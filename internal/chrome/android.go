@@ -0,0 +1,64 @@
+package chrome
+
+import (
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome/find"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+// NewAndroidCookieStore() returns the cookie store of a Chromium-based browser or WebView
+// from Android app data.
+//
+// The cookie values are unencrypted or encrypted with a hardcoded password,
+// there is no keyring to query.
+func NewAndroidCookieStore(browser, profile string, isDefaultProfile bool, filename string) *CookieStore {
+	s := &CookieStore{
+		DefaultCookieStore: cookies.DefaultCookieStore{
+			BrowserStr:           browser,
+			ProfileStr:           profile,
+			OSStr:                `android`,
+			IsDefaultProfileBool: isDefaultProfile,
+			FileNameStr:          filename,
+		},
+	}
+	s.SetNoKeyring(true)
+	return s
+}
+
+// FindAndroidCookieStores() finds the cookie stores of browserName ("chrome", "chromium", "brave")
+// in the Android app data below root.
+func FindAndroidCookieStores(root, browserName string) kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for file, err := range find.FindAndroidCookieStoreFiles(root, browserName) {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			st := NewAndroidCookieStore(file.Browser, file.Profile, file.IsDefaultProfile, file.Path)
+			if !yield(&cookies.CookieJar{CookieStore: st}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// FindAndroidWebViewCookieStores() finds the WebView cookie stores of the apps
+// in the Android app data below root.
+func FindAndroidWebViewCookieStores(root string) kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for file, err := range find.FindAndroidWebViewCookieStoreFiles(root) {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			st := NewAndroidCookieStore(file.Browser, file.Profile, file.IsDefaultProfile, file.Path)
+			if !yield(&cookies.CookieJar{CookieStore: st}, nil) {
+				return
+			}
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"runtime"
 	"slices"
 	"strconv"
@@ -111,6 +112,16 @@ func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
 			if err != nil {
 				return err
 			}
+
+			// columns missing in older databases
+			if sameSite, err := row.Int64(`samesite`); err == nil {
+				cookie.SameSite = sameSiteFromChrome(sameSite)
+			}
+			if partitionKey, err := row.String(`top_frame_site_key`); err == nil && len(partitionKey) > 0 {
+				cookie.Partitioned = true
+				cookie.PartitionKey = partitionKey
+			}
+
 			cookie.Browser = s
 
 			if !yldr(ctx, yield, cookie, nil, valRetr(row)) {
@@ -131,6 +142,21 @@ func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
 	return seq
 }
 
+// sameSiteFromChrome converts the net::CookieSameSite value of the samesite column.
+func sameSiteFromChrome(v int64) http.SameSite {
+	switch v {
+	case 0:
+		return http.SameSiteNoneMode
+	case 1:
+		return http.SameSiteLaxMode
+	case 2:
+		return http.SameSiteStrictMode
+	default:
+		// -1: unspecified
+		return 0
+	}
+}
+
 // query, decrypt and store cookie value
 func (s *CookieStore) saveCookieValue(cookie *kooky.Cookie, row utils.TableRow) error {
 	if cookie.Value != "" {
@@ -213,7 +239,7 @@ func (s *CookieStore) decrypt(encrypted []byte) ([]byte, error) {
 	var decrypt func(encrypted, password []byte, dbVersion int64) ([]byte, error)
 
 	// prioritize previously selected platform then current platform and then other platforms in order of usage on non-server computers
-	// TODO: mobile
+	// Android is only tried for cookie stores marked as such
 	var oss []string
 	for _, opsys := range []string{s.OSStr, runtime.GOOS, `windows`, `darwin`, `linux`} {
 		if slices.Contains(oss, opsys) {
@@ -260,6 +286,13 @@ func (s *CookieStore) decrypt(encrypted []byte) ([]byte, error) {
 			decrypt = func(encrypted, password []byte, dbVersion int64) ([]byte, error) {
 				return decryptAESCBC(encrypted, password, aescbcIterationsMacOS, dbVersion)
 			}
+		case `android`:
+			// Android has no cookie encryption - if a value is encrypted it is by
+			// os_crypt_posix with its hardcoded password as there is no Keystore backed key
+			password = fallbackPasswordLinux[:]
+			decrypt = func(encrypted, password []byte, dbVersion int64) ([]byte, error) {
+				return decryptAESCBC(encrypted, password, aescbcIterationsLinux, dbVersion)
+			}
 		case `linux`:
 			switch {
 			case bytes.HasPrefix(encrypted, []byte(`v12`)):
@@ -289,6 +322,11 @@ func (s *CookieStore) decrypt(encrypted []byte) ([]byte, error) {
 		if needsKeyringQuerying {
 			switch tryNr {
 			case 0, 1:
+				if s.noKeyring {
+					password = fallbackPassword
+					tryNr = 2 // skip querying
+					break
+				}
 				pw, err := s.getKeyringPassword(useSavedKeyringPassword)
 				if err == nil {
 					password = pw
@@ -310,6 +348,7 @@ func (s *CookieStore) decrypt(encrypted []byte) ([]byte, error) {
 			s.DecryptionMethod = decrypt
 			s.OSStr = opsys
 			s.PasswordBytes = password
+			s.encryptedPrefix = bytes.Clone(encrypted[:3]) // encrypted is reused for the next row
 			if len(keyringPassword) > 0 {
 				s.KeyringPasswordBytes = keyringPassword
 			}
@@ -372,6 +411,12 @@ func decryptAESCBC(encrypted, password []byte, iterations int, dbVersion int64)
 	if paddingLen < 1 || paddingLen > 16 {
 		return nil, fmt.Errorf("invalid last block padding length: %d", paddingLen)
 	}
+	// checking all padding bytes makes decryption with a wrong password fail more reliably
+	for _, b := range decrypted[len(decrypted)-paddingLen:] {
+		if int(b) != paddingLen {
+			return nil, errors.New("invalid padding")
+		}
+	}
 
 	// https://chromium-review.googlesource.com/c/chromium/src/+/5792044
 	prefixPaddingLen := 0
@@ -34,7 +34,7 @@ func (s *CookieStore) getKeyringPassword(useSaved bool) ([]byte, error) {
 
 	browser := s.safeStorageApplication()
 
-	kpmKey := `dbus_` + browser
+	kpmKey := `dbus_` + browser + `_` + s.portalAppIDValue()
 	if useSaved {
 		if kpw, ok := keyringPasswordMap.get(kpmKey); ok {
 			return kpw, nil
@@ -58,6 +58,12 @@ func (s *CookieStore) getKeyringPassword(useSaved bool) ([]byte, error) {
 			pw, err = s.getKWalletPassword(``)
 		}
 	}
+	if portalAppID := s.portalAppIDValue(); err != nil && len(portalAppID) > 0 {
+		// secret of a Flatpak installation stored by the xdg-desktop-portal (GNOME)
+		if portalPW, portalErr := s.getSecretServicePasswordByAttributes(map[string]string{`app_id`: portalAppID}); portalErr == nil {
+			pw, err = portalPW, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +77,10 @@ func (s *CookieStore) getKeyringPassword(useSaved bool) ([]byte, error) {
 
 func (s *CookieStore) getSecretServicePassword(browser string) ([]byte, error) {
 	// chromium --password-store=gnome
+	return s.getSecretServicePasswordByAttributes(map[string]string{`application`: browser})
+}
 
+func (s *CookieStore) getSecretServicePasswordByAttributes(search map[string]string) ([]byte, error) {
 	// this is mostly a copy from github.com/zalando/go-keyring (MIT License)
 	// Get()      from https://github.com/zalando/go-keyring/blob/07372e614fb45baa337eaca014ed232b7b196200/keyring_linux.go#L77
 	// findItem() from https://github.com/zalando/go-keyring/blob/07372e614fb45baa337eaca014ed232b7b196200/keyring_linux.go#L51
@@ -86,9 +95,6 @@ func (s *CookieStore) getSecretServicePassword(browser string) ([]byte, error) {
 		return nil, err
 	}
 
-	search := map[string]string{
-		"application": browser,
-	}
 	results, err := svc.SearchItems(collection, search)
 	if err != nil {
 		return nil, err
@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"unsafe"
@@ -59,6 +60,29 @@ func decryptDPAPI(data []byte) ([]byte, error) {
 	return outblob.toByteArray(), nil
 }
 
+// localStateFile returns the "Local State" json file of the user data directory of the cookie store file.
+//
+// It is searched in the parent directories for these layouts:
+//
+//	<user data>/<profile>/[Network/]Cookies
+//	<app>/[Network/]Cookies                     (Electron apps without profiles)
+//	<app>/Partitions/<name>/[Network/]Cookies   (Electron session partitions)
+func localStateFile(cookieFile string) (string, error) {
+	dir := filepath.Dir(cookieFile)
+	for range 4 {
+		stateFile := filepath.Join(dir, `Local State`)
+		if _, err := os.Stat(stateFile); err == nil {
+			return filepath.Abs(stateFile)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return ``, fmt.Errorf(`no "Local State" file above %q`, cookieFile)
+}
+
 // requires the path of the "Local State" json file relative to the cookie store file
 // to be the same as originally
 func (s *CookieStore) getKeyringPassword(useSaved bool) ([]byte, error) {
@@ -71,13 +95,7 @@ func (s *CookieStore) getKeyringPassword(useSaved bool) ([]byte, error) {
 		return s.KeyringPasswordBytes, nil
 	}
 
-	var stateFile string
-	// the "Local State" json file is normally one or two directory above the "Cookies" database
-	dir := filepath.Dir(s.FileNameStr)
-	if filepath.Base(dir) == `Network` { // Chrome 96
-		dir = filepath.Dir(dir)
-	}
-	stateFile, err := filepath.Abs(filepath.Join(filepath.Dir(dir), `Local State`))
+	stateFile, err := localStateFile(s.FileNameStr)
 	if err != nil {
 		return nil, err
 	}
@@ -17,7 +17,9 @@ type CookieStore struct {
 	PasswordBytes        []byte
 	DecryptionMethod     func(data, password []byte, dbVersion int64) ([]byte, error)
 	storage              safeStorage
+	noKeyring            bool
 	dbVersion            int64
+	encryptedPrefix      []byte // "v10", ... of the last decrypted value
 	dbFile               *os.File
 }
 
@@ -76,3 +78,12 @@ func (s *CookieStore) SetKeyringPassword(password []byte) []byte {
 	s.KeyringPasswordBytes = password
 	return oldPassword
 }
+
+// SetNoKeyring() disables the keyring lookups for databases with plain values like those of Qt WebEngine.
+// Encrypted values are only tried to be decrypted with the fallback passwords.
+func (s *CookieStore) SetNoKeyring(noKeyring bool) {
+	if s == nil {
+		return
+	}
+	s.noKeyring = noKeyring
+}
@@ -1,8 +1,13 @@
 package chrome
 
 import (
+	"errors"
 	"strings"
 
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome/find"
+	"github.com/browserutils/kooky/internal/cookies"
+
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -75,3 +80,79 @@ func (s *CookieStore) portalAppIDValue() string {
 	}
 	return s.storage.portalAppID
 }
+
+// SetDerivative() sets the Safe Storage entries of a Chromium derivative.
+func (s *CookieStore) SetDerivative(d *find.Derivative) {
+	if s == nil || d == nil {
+		return
+	}
+	s.SetSafeStorage(d.Account, d.Name, d.Application)
+	s.SetPortalAppID(d.PortalAppID)
+}
+
+// DerivativeCookieStore() returns the cookie store of a Chromium derivative.
+func DerivativeCookieStore(d *find.Derivative, filename string, filters ...kooky.Filter) (*cookies.CookieJar, error) {
+	if d == nil {
+		return nil, errors.New(`derivative is nil`)
+	}
+	s := &CookieStore{}
+	s.FileNameStr = filename
+	s.BrowserStr = d.Browser
+	s.SetDerivative(d)
+
+	return cookies.NewCookieJar(s, filters...), nil
+}
+
+// DerivativeFinder finds the cookie stores and profiles of a Chromium derivative
+// at the locations listed in its find.Derivative.
+type DerivativeFinder struct {
+	Derivative *find.Derivative
+}
+
+var (
+	_ kooky.CookieStoreFinder = (*DerivativeFinder)(nil)
+	_ kooky.ProfileFinder     = (*DerivativeFinder)(nil)
+)
+
+func (f *DerivativeFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		if f == nil || f.Derivative == nil {
+			_ = yield(nil, errors.New(`derivative is nil`))
+			return
+		}
+		for file, err := range f.Derivative.FindCookieStoreFiles() {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			if file == nil {
+				continue
+			}
+			cookieStore := &CookieStore{
+				DefaultCookieStore: cookies.DefaultCookieStore{
+					BrowserStr:           file.Browser,
+					ProfileStr:           file.Profile,
+					OSStr:                file.OS,
+					IsDefaultProfileBool: file.IsDefaultProfile,
+					FileNameStr:          file.Path,
+				},
+			}
+			cookieStore.SetDerivative(f.Derivative)
+			if len(file.PortalAppID) > 0 {
+				cookieStore.SetPortalAppID(file.PortalAppID)
+			}
+			if !yield(&cookies.CookieJar{CookieStore: cookieStore}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (f *DerivativeFinder) FindProfiles() kooky.ProfileSeq {
+	if f == nil || f.Derivative == nil {
+		return func(yield func(*kooky.Profile, error) bool) { _ = yield(nil, errors.New(`derivative is nil`)) }
+	}
+	return KookyProfiles(f.Derivative.FindProfiles())
+}
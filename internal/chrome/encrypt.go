@@ -0,0 +1,114 @@
+package chrome
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"runtime"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// encrypt encrypts a cookie value with the method of the values read before.
+// It returns nil if the value has to be stored unencrypted,
+// Chrome reads the plain "value" column if "encrypted_value" is empty.
+func (s *CookieStore) encrypt(value []byte, hostKey string) ([]byte, error) {
+	prefix, password, opsys := s.encryptionScheme()
+	if len(prefix) == 0 {
+		return nil, nil
+	}
+
+	plaintext := value
+	if s.dbVersion >= 24 {
+		// https://chromium-review.googlesource.com/c/chromium/src/+/5792044
+		hash := sha256.Sum256([]byte(hostKey))
+		plaintext = append(hash[:], value...)
+	}
+
+	switch {
+	case opsys == `windows` && prefix == `v10`:
+		return encryptAES256GCM(prefix, plaintext, password)
+	case opsys == `linux` && prefix == `v12`:
+		hkdfReader := hkdf.New(sha256.New, password, []byte(portalHKDFSalt), []byte(portalHKDFInfo))
+		derivedKey := make([]byte, 32)
+		if _, err := io.ReadFull(hkdfReader, derivedKey); err != nil {
+			return nil, fmt.Errorf("v12 HKDF key derivation: %w", err)
+		}
+		return encryptAES256GCM(prefix, plaintext, derivedKey)
+	case opsys == `darwin` && prefix == `v10`:
+		return encryptAESCBC(prefix, plaintext, password, aescbcIterationsMacOS)
+	case (opsys == `linux` || opsys == `android`) && (prefix == `v10` || prefix == `v11`):
+		return encryptAESCBC(prefix, plaintext, password, aescbcIterationsLinux)
+	default:
+		// DPAPI (before Chrome 80) and App-Bound Encryption can't be reproduced
+		return nil, nil
+	}
+}
+
+// encryptionScheme returns the prefix, password and platform of the encryption of new values.
+// Without decrypted values the method Chrome uses by default on the platform is chosen.
+func (s *CookieStore) encryptionScheme() (prefix string, password []byte, opsys string) {
+	if len(s.encryptedPrefix) > 0 {
+		return string(s.encryptedPrefix), s.PasswordBytes, s.OSStr
+	}
+	if s.noKeyring {
+		return ``, nil, ``
+	}
+	opsys = s.OSStr
+	if len(opsys) == 0 {
+		opsys = runtime.GOOS
+	}
+	switch opsys {
+	case `android`:
+		return `v10`, fallbackPasswordLinux[:], opsys
+	case `linux`:
+		if pw, err := s.getKeyringPassword(true); err == nil {
+			return `v11`, pw, opsys
+		}
+		return `v10`, fallbackPasswordLinux[:], opsys
+	case `darwin`, `windows`:
+		if pw, err := s.getKeyringPassword(true); err == nil {
+			return `v10`, pw, opsys
+		}
+	}
+	return ``, nil, ``
+}
+
+func encryptAESCBC(prefix string, plaintext, password []byte, iterations int) ([]byte, error) {
+	key := pbkdf2.Key(password, []byte(aescbcSalt), iterations, aescbcLength, sha1.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	// PKCS#7 padding
+	paddingLen := aescbcLength - len(plaintext)%aescbcLength
+	padded := append(bytes.Clone(plaintext), bytes.Repeat([]byte{byte(paddingLen)}, paddingLen)...)
+
+	encrypted := make([]byte, len(prefix)+len(padded))
+	copy(encrypted, prefix)
+	cipher.NewCBCEncrypter(block, []byte(aescbcIV)).CryptBlocks(encrypted[len(prefix):], padded)
+	return encrypted, nil
+}
+
+func encryptAES256GCM(prefix string, plaintext, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	encrypted := append([]byte(prefix), nonce...)
+	return aesgcm.Seal(encrypted, nonce, plaintext, nil), nil
+}
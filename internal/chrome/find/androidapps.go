@@ -0,0 +1,84 @@
+package find
+
+import (
+	"iter"
+	"os"
+	"path/filepath"
+
+	"github.com/browserutils/kooky/internal/android"
+)
+
+// androidPackages are the package names of the Chromium-based browsers on Android,
+// the first one is the default channel.
+var androidPackages = map[string][]string{
+	`chrome`:   {`com.android.chrome`, `com.chrome.beta`, `com.chrome.dev`, `com.chrome.canary`},
+	`chromium`: {`org.chromium.chrome`},
+	`brave`:    {`com.brave.browser`, `com.brave.browser_beta`, `com.brave.browser_nightly`},
+}
+
+// FindAndroidCookieStoreFiles yields the cookie stores of browserName ("chrome", "chromium", "brave")
+// in the Android app data below root.
+// The profile is the package name of the app, e.g. "com.chrome.beta".
+func FindAndroidCookieStoreFiles(root, browserName string) iter.Seq2[*chromeCookieStoreFile, error] {
+	return func(yield func(*chromeCookieStoreFile, error) bool) {
+		packages, ok := androidPackages[browserName]
+		if !ok {
+			return
+		}
+		for app := range android.Apps(root, packages...) {
+			for _, path := range androidCookieStorePaths(app, `app_chrome`, `Default`) {
+				file := androidCookieStoreFile(app, browserName, path)
+				file.IsDefaultProfile = app.Package == packages[0] && len(app.User) == 0
+				if !yield(file, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FindAndroidWebViewCookieStoreFiles yields the cookie stores of the Android System WebView
+// used by the apps in the Android app data below root.
+// The profile is the package name of the app embedding the WebView.
+func FindAndroidWebViewCookieStoreFiles(root string) iter.Seq2[*chromeCookieStoreFile, error] {
+	return func(yield func(*chromeCookieStoreFile, error) bool) {
+		for app := range android.Apps(root) {
+			// WebView profiles (Android 13+) in app_webview/Default, before directly in app_webview
+			for _, path := range androidCookieStorePaths(app, `app_webview`, `Default`) {
+				if !yield(androidCookieStoreFile(app, `webview`, path), nil) {
+					return
+				}
+			}
+			for _, path := range androidCookieStorePaths(app, `app_webview`) {
+				if !yield(androidCookieStoreFile(app, `webview`, path), nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// androidCookieStorePaths returns the existing cookie store files in the profile directory of app.
+func androidCookieStorePaths(app *android.App, profileDir ...string) []string {
+	var paths []string
+	for _, rel := range [][]string{{`Network`, `Cookies`}, {`Cookies`}} {
+		path := app.Path(append(profileDir, rel...)...)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func androidCookieStoreFile(app *android.App, browserName, path string) *chromeCookieStoreFile {
+	profile := app.Package
+	if len(app.User) > 0 {
+		profile += ` (user ` + app.User + `)`
+	}
+	return &chromeCookieStoreFile{
+		Path:    filepath.Clean(path),
+		Browser: browserName,
+		Profile: profile,
+		OS:      `android`,
+	}
+}
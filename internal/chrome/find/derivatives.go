@@ -0,0 +1,104 @@
+package find
+
+import (
+	"iter"
+	"slices"
+)
+
+// Derivative describes a Chromium based browser storing its cookies like Chromium does.
+type Derivative struct {
+	Browser string // kooky browser name, e.g. "vivaldi"
+
+	// user data directories relative to the OS specific base directory
+	Linux   [][]string // "${XDG_CONFIG_HOME:-$HOME/.config}"
+	Darwin  [][]string // "$HOME/Library/Application Support"
+	Windows [][]string // "%LocalAppData%"
+
+	Flatpak string     // Flatpak app ID, the Linux directories are also searched in its config directory
+	Snap    [][]string // Snap directories relative to "$HOME/snap"
+
+	// Safe Storage
+	Account     string // macOS Keychain account and KWallet folder prefix, e.g. "Vivaldi"
+	Name        string // Keychain service and KWallet entry, defaults to Account + " Safe Storage"
+	Application string // Secret Service "application" attribute, e.g. "vivaldi"
+	PortalAppID string // xdg-desktop-portal app ID (Flatpak), e.g. "com.vivaldi.Vivaldi"
+}
+
+// Derivatives lists the Chromium derivatives without their own browser specific handling.
+var Derivatives = []*Derivative{
+	{
+		Browser:     `arc`,
+		Darwin:      [][]string{{`Arc`, `User Data`}},
+		Windows:     [][]string{{`Packages`, `TheBrowserCompany.Arc_ttt1ap7aakyb4`, `LocalCache`, `Local`, `Arc`, `User Data`}},
+		Account:     `Arc`,
+		Application: `arc`,
+	},
+	{
+		Browser:     `coccoc`,
+		Linux:       [][]string{{`coccoc`}},
+		Darwin:      [][]string{{`Coccoc`}},
+		Windows:     [][]string{{`CocCoc`, `Browser`, `User Data`}},
+		Account:     `CocCoc`,
+		Application: `coccoc`,
+	},
+	{
+		Browser:     `thorium`,
+		Linux:       [][]string{{`thorium`}},
+		Darwin:      [][]string{{`Thorium`}},
+		Windows:     [][]string{{`Thorium`, `User Data`}},
+		Account:     `Thorium`,
+		Application: `thorium`,
+	},
+	{
+		Browser:     `vivaldi`,
+		Linux:       [][]string{{`vivaldi`}, {`vivaldi-snapshot`}},
+		Flatpak:     `com.vivaldi.Vivaldi`,
+		Snap:        [][]string{{`vivaldi`, `current`, `.config`, `vivaldi`}},
+		Darwin:      [][]string{{`Vivaldi`}},
+		Windows:     [][]string{{`Vivaldi`, `User Data`}},
+		Account:     `Vivaldi`,
+		Application: `vivaldi`,
+		PortalAppID: `com.vivaldi.Vivaldi`,
+	},
+	{
+		Browser:     `whale`,
+		Linux:       [][]string{{`naver-whale`}},
+		Darwin:      [][]string{{`Naver`, `Whale`}},
+		Windows:     [][]string{{`Naver`, `Naver Whale`, `User Data`}},
+		Account:     `Whale`,
+		Application: `whale`,
+	},
+	{
+		Browser:     `yandex`,
+		Linux:       [][]string{{`yandex-browser`}, {`yandex-browser-beta`}},
+		Darwin:      [][]string{{`Yandex`, `YandexBrowser`}},
+		Windows:     [][]string{{`Yandex`, `YandexBrowser`, `User Data`}},
+		Account:     `Yandex`,
+		Application: `yandex-browser`,
+	},
+}
+
+// LookupDerivative() returns the Derivative with the browser name or nil.
+func LookupDerivative(browser string) *Derivative {
+	i := slices.IndexFunc(Derivatives, func(d *Derivative) bool { return d.Browser == browser })
+	if i < 0 {
+		return nil
+	}
+	return Derivatives[i]
+}
+
+// Roots() yields the user data directories of the derivative on the current OS.
+func (d *Derivative) Roots(yield func(string, error) bool) {
+	if d == nil {
+		return
+	}
+	derivativeRoots(d)(yield)
+}
+
+func (d *Derivative) FindCookieStoreFiles() iter.Seq2[*chromeCookieStoreFile, error] {
+	return FindCookieStoreFiles(d.Roots, d.Browser)
+}
+
+func (d *Derivative) FindProfiles() iter.Seq2[*Profile, error] {
+	return FindProfiles(d.Roots, d.Browser)
+}
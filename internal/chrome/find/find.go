@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"iter"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
+
+	"github.com/browserutils/kooky/internal/sandbox"
 )
 
 type chromeCookieStoreFile struct {
@@ -15,6 +19,20 @@ type chromeCookieStoreFile struct {
 	Profile          string
 	OS               string
 	IsDefaultProfile bool
+	PortalAppID      string // app ID of Flatpak installations
+}
+
+// Profile represents a Chromium-based browser profile listed in the info_cache of the "Local State" file.
+type Profile struct {
+	Path             string // profile directory
+	Browser          string
+	Name             string
+	IsDefaultProfile bool   // last used profile
+	UsingDefaultName bool   // Name is a generic name like "Person 1" and not chosen by the user
+	GAIAName         string // name of the signed in Google account
+	UserName         string // email address of the signed in Google account
+	ActiveTime       time.Time
+	OS               string
 }
 
 // chromeRoots and chromiumRoots could be put into the github.com/kooky/browser/{chrome,chromium} packages.
@@ -30,8 +48,20 @@ func FindBraveCookieStoreFiles() iter.Seq2[*chromeCookieStoreFile, error] {
 	return FindCookieStoreFiles(braveRoots, `brave`)
 }
 
-func FindCookieStoreFiles(rootsFunc iter.Seq2[string, error], browserName string) iter.Seq2[*chromeCookieStoreFile, error] {
-	return func(yield func(*chromeCookieStoreFile, error) bool) {
+func FindChromeProfiles() iter.Seq2[*Profile, error] {
+	return FindProfiles(chromeRoots, `chrome`)
+}
+func FindChromiumProfiles() iter.Seq2[*Profile, error] {
+	return FindProfiles(chromiumRoots, `chromium`)
+}
+func FindBraveProfiles() iter.Seq2[*Profile, error] {
+	return FindProfiles(braveRoots, `brave`)
+}
+
+// FindProfiles lazily iterates root directories and yields the profiles
+// listed in the "Local State" file of each root.
+func FindProfiles(rootsFunc iter.Seq2[string, error], browserName string) iter.Seq2[*Profile, error] {
+	return func(yield func(*Profile, error) bool) {
 		if rootsFunc == nil {
 			_ = yield(nil, errors.New(`passed roots function is nil`))
 			return
@@ -45,6 +75,12 @@ func FindCookieStoreFiles(rootsFunc iter.Seq2[string, error], browserName string
 			}
 			localStateBytes, err := os.ReadFile(filepath.Join(root, `Local State`))
 			if err != nil {
+				if p := profileLessProfile(root, browserName); p != nil {
+					if !yield(p, nil) {
+						return
+					}
+					continue
+				}
 				if !yield(nil, err) {
 					return
 				}
@@ -52,9 +88,13 @@ func FindCookieStoreFiles(rootsFunc iter.Seq2[string, error], browserName string
 			}
 			var localState struct {
 				Profile struct {
+					LastUsed  string `json:"last_used"`
 					InfoCache map[string]struct {
-						IsUsingDefaultName bool `json:"is_using_default_name"`
-						Name               string
+						IsUsingDefaultName bool    `json:"is_using_default_name"`
+						Name               string  `json:"name"`
+						GAIAName           string  `json:"gaia_name"`
+						UserName           string  `json:"user_name"`
+						ActiveTime         float64 `json:"active_time"`
 					} `json:"info_cache"`
 				}
 			}
@@ -63,51 +103,118 @@ func FindCookieStoreFiles(rootsFunc iter.Seq2[string, error], browserName string
 				if !yield(nil, err) {
 					return
 				}
-				st := &chromeCookieStoreFile{
+				p := &Profile{
+					Path:             filepath.Join(root, `Default`),
 					Browser:          browserName,
-					Profile:          `Profile 1`,
+					Name:             `Profile 1`,
 					IsDefaultProfile: true,
-					Path:             filepath.Join(root, `Default`, `Network`, `Cookies`), // Chrome 96
 					OS:               runtime.GOOS,
 				}
-				if !yield(st, nil) {
+				if !yield(p, nil) {
 					return
 				}
-				st = &chromeCookieStoreFile{
-					Browser:          browserName,
-					Profile:          `Profile 1`,
-					IsDefaultProfile: true,
-					Path:             filepath.Join(root, `Default`, `Cookies`),
-					OS:               runtime.GOOS,
-				}
-				if !yield(st, nil) {
-					return
+				continue
+			}
+			if len(localState.Profile.InfoCache) == 0 {
+				if p := profileLessProfile(root, browserName); p != nil {
+					if !yield(p, nil) {
+						return
+					}
 				}
 				continue
 			}
+			// the profile Chrome opens on start
+			defaultDir := localState.Profile.LastUsed
+			if _, ok := localState.Profile.InfoCache[defaultDir]; !ok {
+				defaultDir = `Default`
+			}
 			for profDir, profStr := range localState.Profile.InfoCache {
-				st := &chromeCookieStoreFile{
-
+				p := &Profile{
+					Path:             filepath.Join(root, profDir),
 					Browser:          browserName,
-					Profile:          profStr.Name,
-					IsDefaultProfile: profStr.IsUsingDefaultName,
-					Path:             filepath.Join(root, profDir, `Network`, `Cookies`), // Chrome 96
+					Name:             profStr.Name,
+					IsDefaultProfile: profDir == defaultDir,
+					UsingDefaultName: profStr.IsUsingDefaultName,
+					GAIAName:         profStr.GAIAName,
+					UserName:         profStr.UserName,
 					OS:               runtime.GOOS,
 				}
-				if !yield(st, nil) {
+				if profStr.ActiveTime > 0 {
+					// seconds since the unix epoch with fractional part
+					sec, frac := math.Modf(profStr.ActiveTime)
+					p.ActiveTime = time.Unix(int64(sec), int64(frac*1e9))
+				}
+				if !yield(p, nil) {
 					return
 				}
-				st = &chromeCookieStoreFile{
-					Browser:          browserName,
-					Profile:          profStr.Name,
-					IsDefaultProfile: profStr.IsUsingDefaultName,
-					Path:             filepath.Join(root, profDir, `Cookies`),
-					OS:               runtime.GOOS,
+			}
+		}
+	}
+}
+
+// profileLessProfile returns the root as profile if the cookie store is directly in it.
+// Electron apps use this layout without profiles listed in the "Local State" file.
+func profileLessProfile(root, browserName string) *Profile {
+	for _, path := range []string{
+		filepath.Join(root, `Network`, `Cookies`),
+		filepath.Join(root, `Cookies`),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		return &Profile{
+			Path:             root,
+			Browser:          browserName,
+			Name:             filepath.Base(root),
+			IsDefaultProfile: true,
+			OS:               runtime.GOOS,
+		}
+	}
+	return nil
+}
+
+func FindCookieStoreFiles(rootsFunc iter.Seq2[string, error], browserName string) iter.Seq2[*chromeCookieStoreFile, error] {
+	return func(yield func(*chromeCookieStoreFile, error) bool) {
+		for p, err := range FindProfiles(rootsFunc, browserName) {
+			if err != nil {
+				if !yield(nil, err) {
+					return
 				}
-				if !yield(st, nil) {
+				continue
+			}
+			if p == nil {
+				continue
+			}
+			for file := range CookieStoreFilesForProfile(p) {
+				if !yield(file, nil) {
 					return
 				}
 			}
 		}
 	}
 }
+
+// CookieStoreFilesForProfile yields the possible cookie store file locations of a profile.
+func CookieStoreFilesForProfile(p *Profile) iter.Seq[*chromeCookieStoreFile] {
+	return func(yield func(*chromeCookieStoreFile) bool) {
+		if p == nil {
+			return
+		}
+		for _, path := range []string{
+			filepath.Join(p.Path, `Network`, `Cookies`), // Chrome 96
+			filepath.Join(p.Path, `Cookies`),
+		} {
+			st := &chromeCookieStoreFile{
+				Browser:          p.Browser,
+				Profile:          p.Name,
+				IsDefaultProfile: p.IsDefaultProfile,
+				Path:             path,
+				OS:               p.OS,
+				PortalAppID:      sandbox.FlatpakAppID(p.Path),
+			}
+			if !yield(st) {
+				return
+			}
+		}
+	}
+}
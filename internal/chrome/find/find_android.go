@@ -3,17 +3,31 @@
 
 package find
 
-import "errors"
+import (
+	"errors"
+	"path/filepath"
+)
 
 var errNotImplemented = errors.New(`not implemented`)
 
-func chromeRoots(yield func(string, error) bool) {
-	// https://chromium.googlesource.com/chromium/src.git/+/62.0.3202.58/docs/user_data_dir.md#android
-	if !yield(`/data/user/0/com.android.chrome/app_chrome`, nil) { // TODO check
-		return
+// https://chromium.googlesource.com/chromium/src.git/+/62.0.3202.58/docs/user_data_dir.md#android
+// only readable by the app itself or with root access
+func androidRoots(browserName string) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		for _, pkg := range androidPackages[browserName] {
+			if !yield(filepath.Join(`/data/data`, pkg, `app_chrome`), nil) {
+				return
+			}
+		}
 	}
 }
 
-func chromiumRoots(yield func(string, error) bool) { _ = yield(``, errNotImplemented) }
+func chromeRoots(yield func(string, error) bool) { androidRoots(`chrome`)(yield) }
 
-func braveRoots(yield func(string, error) bool) { _ = yield(``, errNotImplemented) }
+func chromiumRoots(yield func(string, error) bool) { androidRoots(`chromium`)(yield) }
+
+func braveRoots(yield func(string, error) bool) { androidRoots(`brave`)(yield) }
+
+func derivativeRoots(d *Derivative) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) { _ = yield(``, errNotImplemented) }
+}
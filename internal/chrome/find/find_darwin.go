@@ -47,3 +47,19 @@ func braveRoots(yield func(string, error) bool) {
 		return
 	}
 }
+
+func derivativeRoots(d *Derivative) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		// "$HOME/Library/Application Support"
+		cfgDir, err := os.UserConfigDir()
+		if err != nil {
+			_ = yield(``, err)
+			return
+		}
+		for _, pathParts := range d.Darwin {
+			if !yield(filepath.Join(append([]string{cfgDir}, pathParts...)...), nil) {
+				return
+			}
+		}
+	}
+}
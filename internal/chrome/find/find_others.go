@@ -11,3 +11,7 @@ func chromeRoots(yield func(string, error) bool) { _ = yield(``, errNotImplement
 func chromiumRoots(yield func(string, error) bool) { _ = yield(``, errNotImplemented) }
 
 func braveRoots(yield func(string, error) bool) { _ = yield(``, errNotImplemented) }
+
+func derivativeRoots(d *Derivative) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) { _ = yield(``, errNotImplemented) }
+}
@@ -7,3 +7,7 @@ package find
 func windowsChromeRoots(yield func(string, error) bool)   {}
 func windowsChromiumRoots(yield func(string, error) bool) {}
 func windowsBraveRoots(yield func(string, error) bool)    {}
+
+func windowsDerivativeRoots(d *Derivative) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {}
+}
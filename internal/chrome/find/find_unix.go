@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/browserutils/kooky/internal/sandbox"
 	"github.com/browserutils/kooky/internal/windowsx"
 )
 
@@ -41,6 +42,13 @@ func chromeRoots(yield func(string, error) bool) {
 			)
 		}
 	}
+	if home, err := os.UserHomeDir(); err == nil {
+		ret = append(
+			ret,
+			sandbox.FlatpakConfigDir(home, `com.google.Chrome`, `google-chrome`),
+			sandbox.FlatpakConfigDir(home, `com.google.ChromeDev`, `google-chrome-unstable`),
+		)
+	}
 	for _, r := range ret {
 		if !yield(r, nil) {
 			return
@@ -76,6 +84,16 @@ func chromiumRoots(yield func(string, error) bool) {
 			return
 		}
 	}
+	if home, err := os.UserHomeDir(); err == nil {
+		for _, p := range []string{
+			sandbox.FlatpakConfigDir(home, `org.chromium.Chromium`, `chromium`),
+			sandbox.SnapDir(home, `chromium`, `common`, `chromium`),
+		} {
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
 	// on WSL Linux add Windows paths
 	if !windowsx.IsWSL() {
 		return
@@ -111,6 +129,16 @@ func braveRoots(yield func(string, error) bool) {
 			}
 		}
 	}
+	if home, err := os.UserHomeDir(); err == nil {
+		for _, p := range []string{
+			sandbox.FlatpakConfigDir(home, `com.brave.Browser`, `BraveSoftware`, `Brave-Browser`),
+			sandbox.SnapDir(home, `brave`, `current`, `.config`, `BraveSoftware`, `Brave-Browser`),
+		} {
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
 	// on WSL Linux add Windows paths
 	if !windowsx.IsWSL() {
 		return
@@ -121,3 +149,49 @@ func braveRoots(yield func(string, error) bool) {
 		}
 	}
 }
+
+func derivativeRoots(d *Derivative) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		// "${XDG_CONFIG_HOME:-$HOME/.config}"
+		var dotConfigs []string
+		// fallback
+		if home, err := os.UserHomeDir(); err != nil {
+			if !yield(``, err) {
+				return
+			}
+		} else {
+			dotConfigs = append(dotConfigs, filepath.Join(home, `.config`))
+		}
+		if dir, ok := os.LookupEnv(`XDG_CONFIG_HOME`); ok {
+			dotConfigs = append(dotConfigs, dir)
+		}
+		for _, dotConfig := range dotConfigs {
+			for _, pathParts := range d.Linux {
+				if !yield(filepath.Join(append([]string{dotConfig}, pathParts...)...), nil) {
+					return
+				}
+			}
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			for _, pathParts := range d.Linux {
+				if len(d.Flatpak) > 0 && !yield(sandbox.FlatpakConfigDir(home, d.Flatpak, pathParts...), nil) {
+					return
+				}
+			}
+			for _, pathParts := range d.Snap {
+				if !yield(sandbox.SnapDir(home, pathParts...), nil) {
+					return
+				}
+			}
+		}
+		// on WSL Linux add Windows paths
+		if !windowsx.IsWSL() {
+			return
+		}
+		for r, err := range windowsDerivativeRoots(d) {
+			if !yield(r, err) {
+				return
+			}
+		}
+	}
+}
@@ -44,3 +44,15 @@ func locAppRoots(pathParts ...string) func(yield func(string, error) bool) {
 		}
 	}
 }
+
+func windowsDerivativeRoots(d *Derivative) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		for _, pathParts := range d.Windows {
+			for r, err := range locAppRoots(pathParts...) {
+				if !yield(r, err) {
+					return
+				}
+			}
+		}
+	}
+}
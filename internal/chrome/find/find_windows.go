@@ -6,4 +6,6 @@ var (
 	chromeRoots   = windowsChromeRoots
 	chromiumRoots = windowsChromiumRoots
 	braveRoots    = windowsBraveRoots
+
+	derivativeRoots = windowsDerivativeRoots
 )
@@ -0,0 +1,42 @@
+package chrome
+
+import (
+	"iter"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/chrome/find"
+)
+
+// KookyProfiles converts profiles listed in "Local State" files to kooky.Profile.
+func KookyProfiles(profiles iter.Seq2[*find.Profile, error]) kooky.ProfileSeq {
+	return func(yield func(*kooky.Profile, error) bool) {
+		for p, err := range profiles {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			if p == nil {
+				continue
+			}
+			displayName := p.Name
+			if p.UsingDefaultName && len(p.GAIAName) > 0 {
+				// Chrome shows the account name for profiles with generic names
+				displayName = p.GAIAName
+			}
+			kp := &kooky.Profile{
+				Browser:      p.Browser,
+				Name:         p.Name,
+				Dir:          p.Path,
+				IsDefault:    p.IsDefaultProfile,
+				DisplayName:  displayName,
+				LastUsed:     p.ActiveTime,
+				AccountEmail: p.UserName,
+			}
+			if !yield(kp, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package chrome
+
+import (
+	"runtime"
+
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+// NewQtWebEngineCookieStore() returns the cookie store of a Qt WebEngine profile.
+//
+// Qt WebEngine stores the cookie values unencrypted, so the keyring is never queried.
+func NewQtWebEngineCookieStore(browser, profile string, isDefaultProfile bool, filename string) *CookieStore {
+	s := &CookieStore{
+		DefaultCookieStore: cookies.DefaultCookieStore{
+			BrowserStr:           browser,
+			ProfileStr:           profile,
+			OSStr:                runtime.GOOS,
+			IsDefaultProfileBool: isDefaultProfile,
+			FileNameStr:          filename,
+		},
+	}
+	s.SetNoKeyring(true)
+	return s
+}
@@ -0,0 +1,183 @@
+package chrome
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/sqlitex"
+	"github.com/browserutils/kooky/internal/timex"
+)
+
+var (
+	_ kooky.CookieWriter       = (*CookieStore)(nil)
+	_ kooky.CapabilityReporter = (*CookieStore)(nil)
+)
+
+func (s *CookieStore) Capabilities() kooky.StoreCapabilities {
+	return kooky.StoreCapabilities{Writable: sqlitex.Enabled(), Partitions: true}
+}
+
+// WriteCookies writes the cookies to the "Cookies" database.
+// The browser has to be closed, it locks the database while running.
+// Writing has to be enabled by importing github.com/browserutils/kooky/sqlitewrite.
+//
+// Values are encrypted with the method of the values already stored
+// or the default method of the platform.
+// If it can't be reproduced (DPAPI, App-Bound Encryption), values are stored unencrypted.
+func (s *CookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	// learn the database version and the encryption method from the stored values
+	for range s.TraverseCookies() {
+		if len(s.encryptedPrefix) > 0 {
+			break
+		}
+	}
+	if err := s.Close(); err != nil {
+		return err
+	}
+
+	db, err := sqlitex.Open(s.FileNameStr)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	table, err := sqlitex.ReadTable(tx, `cookies`)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, c := range kookies {
+		if c == nil || len(c.Domain) == 0 {
+			continue
+		}
+		if err := s.writeCookie(tx, table, c, now); err != nil {
+			return fmt.Errorf("cookie %q: %w", c.Name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *CookieStore) writeCookie(tx *sql.Tx, table *sqlitex.Table, c *kooky.Cookie, now time.Time) error {
+	var partitionKey string
+	if c.Partitioned {
+		partitionKey = c.PartitionKey
+	}
+
+	del := `DELETE FROM cookies WHERE host_key = ? AND name = ? AND path = ?`
+	args := []any{c.Domain, c.Name, c.Path}
+	if table.Has(`top_frame_site_key`) {
+		del += ` AND top_frame_site_key = ?`
+		args = append(args, partitionKey)
+	}
+	if _, err := tx.Exec(del, args...); err != nil {
+		return err
+	}
+	if c.MaxAge < 0 || (!c.Expires.IsZero() && c.Expires.Before(now)) {
+		// deletion
+		return nil
+	}
+
+	value := c.Value
+	encrypted, err := s.encrypt([]byte(c.Value), c.Domain)
+	if err != nil {
+		return err
+	}
+	if encrypted != nil {
+		value = ``
+	} else {
+		encrypted = []byte{}
+	}
+
+	creation := c.Creation
+	if creation.IsZero() {
+		creation = now
+	}
+	// creation_utc is the primary key in older databases
+	creationUTC := toChromeTime(creation)
+	for {
+		var n int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM cookies WHERE creation_utc = ?`, creationUTC).Scan(&n); err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		creationUTC++
+	}
+	var expiresUTC int64
+	if !c.Expires.IsZero() {
+		expiresUTC = toChromeTime(c.Expires)
+	}
+	persistent := expiresUTC != 0
+
+	return table.Insert(tx, map[string]any{
+		`creation_utc`:            creationUTC,
+		`host_key`:                c.Domain,
+		`top_frame_site_key`:      partitionKey,
+		`name`:                    c.Name,
+		`value`:                   value,
+		`encrypted_value`:         encrypted,
+		`path`:                    c.Path,
+		`expires_utc`:             expiresUTC,
+		`is_secure`:               c.Secure,
+		`is_httponly`:             c.HttpOnly,
+		`secure`:                  c.Secure,   // before Chrome 66
+		`httponly`:                c.HttpOnly, // before Chrome 66
+		`last_access_utc`:         toChromeTime(now),
+		`last_update_utc`:         toChromeTime(now),
+		`has_expires`:             persistent,
+		`is_persistent`:           persistent,
+		`persistent`:              persistent, // before Chrome 71
+		`priority`:                1,          // medium
+		`samesite`:                sameSiteToChrome(c.SameSite),
+		`source_scheme`:           0,  // unset
+		`source_port`:             -1, // unspecified
+		`source_type`:             0,  // unknown
+		`has_cross_site_ancestor`: len(partitionKey) > 0 && !sameSite(c.Domain, partitionKey),
+	})
+}
+
+// toChromeTime converts to microseconds since 1601, the inverse of timex.FromFILETIME(t * 10).
+func toChromeTime(t time.Time) int64 {
+	return timex.ToFILETIME(t) / 10
+}
+
+// sameSiteToChrome is the inverse of sameSiteFromChrome.
+func sameSiteToChrome(s http.SameSite) int {
+	switch s {
+	case http.SameSiteNoneMode:
+		return 0
+	case http.SameSiteLaxMode:
+		return 1
+	case http.SameSiteStrictMode:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// sameSite reports whether the cookie domain belongs to the site of the partition key.
+func sameSite(domain, partitionKey string) bool {
+	domain = strings.TrimPrefix(domain, `.`)
+	_, host, _ := strings.Cut(partitionKey, `://`)
+	host, _, _ = strings.Cut(host, `:`)
+	site, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	if err != nil {
+		return domain == host
+	}
+	return site == host
+}
@@ -24,6 +24,7 @@ type CookieJar struct {
 	initErr error
 	filters []kooky.Filter
 	cookies kooky.Cookies // duplicate storage required for SubJar()
+	persist persistence
 	CookieStore
 }
 
@@ -60,6 +61,8 @@ func (s *CookieJar) InitJar() error {
 	s.init.Do(func() {
 		ctx := context.Background()
 		var kookies []*kooky.Cookie
+		s.persist.mu.Lock()
+		defer s.persist.mu.Unlock()
 		if s.CookieStore != nil && len(s.cookies) == 0 {
 			var err error
 			kookies, err = s.CookieStore.TraverseCookies(s.filters...).ReadAllCookies(ctx)
@@ -79,7 +82,7 @@ func (s *CookieJar) InitJar() error {
 		s.Jar = jar
 		s.cookies = kookies
 		cookies := kookies2cookies(ctx, kookies)
-		setAllCookies(s, cookies)
+		setAllCookies(s.Jar, cookies)
 	})
 
 	return s.initErr
@@ -95,7 +98,9 @@ func (s *CookieJar) SubJar(ctx context.Context, filters ...kooky.Filter) (http.C
 	if err := s.InitJar(); err != nil {
 		return nil, err
 	}
+	s.persist.mu.Lock()
 	kookies := kooky.FilterCookies(ctx, s.cookies, filters...).Collect(ctx)
+	s.persist.mu.Unlock()
 	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
 	if err != nil {
 		return nil, err
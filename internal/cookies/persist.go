@@ -0,0 +1,238 @@
+package cookies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/browserutils/kooky"
+)
+
+// SidecarReader and SidecarWriter handle netscape cookies.txt sidecar files.
+// They are set by internal/netscape which can't be imported here (import cycle).
+var (
+	SidecarReader func(filename string) ([]*kooky.Cookie, error)
+	SidecarWriter func(filename string, cookies ...*kooky.Cookie) error
+)
+
+type persistence struct {
+	mu      sync.Mutex
+	enabled bool
+	sidecar string
+}
+
+var (
+	_ kooky.PersistentCookieStore = (*CookieJar)(nil)
+	_ kooky.CookieWriter          = (*CookieJar)(nil)
+	_ kooky.CapabilityReporter    = (*CookieJar)(nil)
+)
+
+// Capabilities reports the capabilities of the wrapped cookie store.
+func (s *CookieJar) Capabilities() kooky.StoreCapabilities {
+	if s == nil {
+		return kooky.StoreCapabilities{}
+	}
+	return kooky.CapabilitiesOf(s.CookieStore)
+}
+
+// WriteCookies writes the cookies to the cookie store if it implements kooky.CookieWriter.
+func (s *CookieJar) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`nil receiver`)
+	}
+	w, ok := s.CookieStore.(kooky.CookieWriter)
+	if !ok {
+		return kooky.ErrNotWritable
+	}
+	return w.WriteCookies(kookies...)
+}
+
+func (s *CookieJar) Persist(sidecar string) error {
+	if s == nil {
+		return errors.New(`nil receiver`)
+	}
+	if s.CookieStore == nil {
+		return errors.New(`no cookie store set`)
+	}
+	if len(sidecar) == 0 {
+		if _, ok := s.CookieStore.(kooky.CookieWriter); !ok {
+			return fmt.Errorf(`%w; use a sidecar file`, kooky.ErrNotWritable)
+		}
+	} else if SidecarWriter == nil || SidecarReader == nil {
+		return errors.New(`no sidecar file support`)
+	}
+	if err := s.InitJar(); err != nil {
+		return err
+	}
+	if len(sidecar) > 0 {
+		kookies, err := SidecarReader(sidecar)
+		if err != nil {
+			return err
+		}
+		setAllCookies(s.Jar, kookies2cookies(context.Background(), kookies))
+		s.persist.mu.Lock()
+		// sidecar cookies are newer than those of the cookie store
+		s.cookies = MergeCookies(s.cookies, kookies, time.Now())
+		s.persist.mu.Unlock()
+	}
+
+	s.persist.mu.Lock()
+	defer s.persist.mu.Unlock()
+	s.persist.enabled = true
+	s.persist.sidecar = sidecar
+	return nil
+}
+
+// SetCookies implements the http.CookieJar interface.
+// If persistence is enabled with Persist(), the cookies are also written back.
+func (s *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	if s == nil || u == nil {
+		return
+	}
+	if err := s.InitJar(); err != nil || s.Jar == nil {
+		return
+	}
+	s.Jar.SetCookies(u, cookies)
+
+	s.persist.mu.Lock()
+	defer s.persist.mu.Unlock()
+	if !s.persist.enabled {
+		return
+	}
+	now := time.Now()
+	var kookies []*kooky.Cookie
+	for _, c := range cookies {
+		if c == nil {
+			continue
+		}
+		// only persist what the jar accepted
+		if k := ResponseCookie(u, c, now, s.CookieStore); k != nil {
+			kookies = append(kookies, k)
+		}
+	}
+	if len(kookies) == 0 {
+		return
+	}
+	s.cookies = MergeCookies(s.cookies, kookies, now)
+	// http.CookieJar has no way to report errors
+	if len(s.persist.sidecar) > 0 {
+		_ = SidecarWriter(s.persist.sidecar, kookies...)
+	} else if w, ok := s.CookieStore.(kooky.CookieWriter); ok {
+		_ = w.WriteCookies(kookies...)
+	}
+}
+
+// ResponseCookie converts a cookie received from u at time now to the stored form (RFC 6265, section 5.3).
+// Host-only cookies have a domain without leading dot, deleted cookies expire in 1970.
+// It returns nil for cookies which u may not set.
+func ResponseCookie(u *url.URL, c *http.Cookie, now time.Time, bi kooky.BrowserInfo) *kooky.Cookie {
+	host := strings.ToLower(u.Hostname())
+	if len(host) == 0 {
+		return nil
+	}
+	if c.Secure && u.Scheme != `https` && u.Scheme != `wss` {
+		return nil
+	}
+	k := &kooky.Cookie{Cookie: *c, Creation: now, Browser: bi}
+	domain := strings.ToLower(strings.TrimPrefix(k.Domain, `.`))
+	switch {
+	case len(domain) == 0:
+		// host-only cookie
+		k.Domain = host
+	case domain == host && (net.ParseIP(host) != nil || isPublicSuffix(domain)):
+		// an IP address or a public suffix can only set host-only cookies
+		k.Domain = host
+	case net.ParseIP(host) != nil || isPublicSuffix(domain):
+		return nil
+	case host == domain || strings.HasSuffix(host, `.`+domain):
+		k.Domain = `.` + domain
+	default:
+		// domain does not match
+		return nil
+	}
+	if len(k.Path) == 0 || !strings.HasPrefix(k.Path, `/`) {
+		k.Path = defaultPath(u.Path)
+	}
+	switch {
+	case c.MaxAge < 0:
+		k.Expires = time.Unix(1, 0) // deletion
+	case c.MaxAge > 0:
+		k.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+	}
+	k.MaxAge = 0
+	return k
+}
+
+func isPublicSuffix(domain string) bool {
+	ps, _ := publicsuffix.PublicSuffix(domain)
+	return ps == domain
+}
+
+// defaultPath returns the default cookie path (RFC 6265, section 5.1.4).
+func defaultPath(p string) string {
+	if len(p) == 0 || p[0] != '/' {
+		return `/`
+	}
+	dir := path.Dir(p)
+	if dir == `.` {
+		return `/`
+	}
+	return dir
+}
+
+// MergeCookies returns the existing cookies updated by updates.
+// Cookies are identified by domain, path, name, container and the partition key of partitioned cookies.
+// Updates with negative MaxAge or an expiry date before now delete cookies.
+func MergeCookies(existing, updates []*kooky.Cookie, now time.Time) []*kooky.Cookie {
+	type key struct{ domain, path, name, container, partitionKey string }
+	keyOf := func(c *kooky.Cookie) key {
+		k := key{domain: c.Domain, path: c.Path, name: c.Name, container: c.Container}
+		if c.Partitioned {
+			k.partitionKey = c.PartitionKey
+		}
+		return k
+	}
+
+	ret := make([]*kooky.Cookie, 0, len(existing)+len(updates))
+	idx := make(map[key]int)
+	for _, c := range existing {
+		if c == nil {
+			continue
+		}
+		if i, ok := idx[keyOf(c)]; ok {
+			ret[i] = c
+			continue
+		}
+		idx[keyOf(c)] = len(ret)
+		ret = append(ret, c)
+	}
+	deleted := make(map[key]bool)
+	for _, c := range updates {
+		if c == nil {
+			continue
+		}
+		k := keyOf(c)
+		del := c.MaxAge < 0 || (!c.Expires.IsZero() && c.Expires.Before(now))
+		deleted[k] = del
+		if i, ok := idx[k]; ok {
+			ret[i] = c
+			continue
+		}
+		idx[k] = len(ret)
+		ret = append(ret, c)
+	}
+	if len(deleted) > 0 {
+		ret = slices.DeleteFunc(ret, func(c *kooky.Cookie) bool { return deleted[keyOf(c)] })
+	}
+	return ret
+}
@@ -0,0 +1,41 @@
+package cookies
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+)
+
+func TestMergeCookiesPartitions(t *testing.T) {
+	newCookie := func(value, container, partitionKey string) *kooky.Cookie {
+		c := &kooky.Cookie{Container: container}
+		c.Cookie = http.Cookie{Name: `sid`, Value: value, Domain: `.example.com`, Path: `/`}
+		if len(partitionKey) > 0 {
+			c.Partitioned = true
+			c.PartitionKey = partitionKey
+		}
+		return c
+	}
+	existing := []*kooky.Cookie{
+		newCookie(`a`, ``, `https://a.test`),
+		newCookie(`b`, ``, `https://b.test`),
+		newCookie(`personal`, `Personal`, ``),
+		newCookie(`work`, `Work`, ``),
+	}
+	del := newCookie(``, `Work`, ``)
+	del.MaxAge = -1
+	updates := []*kooky.Cookie{newCookie(`a2`, ``, `https://a.test`), del}
+
+	merged := MergeCookies(existing, updates, time.Now())
+	var got []string
+	for _, c := range merged {
+		got = append(got, c.Value)
+	}
+	want := []string{`a2`, `b`, `personal`}
+	if !slices.Equal(got, want) {
+		t.Errorf("got values %q; want %q", got, want)
+	}
+}
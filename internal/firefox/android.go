@@ -0,0 +1,40 @@
+package firefox
+
+import (
+	"path/filepath"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/firefox/find"
+)
+
+// FindFenixCookieStores() finds the cookie stores of Firefox for Android (Fenix)
+// in the Android app data below root.
+//
+// Fenix doesn't keep session cookies in a session store file.
+func FindFenixCookieStores(root string) kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for p, err := range find.FindFenixProfiles(root) {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			st := &cookies.CookieJar{
+				CookieStore: &CookieStore{
+					DefaultCookieStore: cookies.DefaultCookieStore{
+						BrowserStr:           p.Browser,
+						ProfileStr:           p.Name,
+						OSStr:                `android`,
+						IsDefaultProfileBool: p.IsDefaultProfile,
+						FileNameStr:          filepath.Join(p.Path, `cookies.sqlite`),
+					},
+				},
+			}
+			if !yield(st, nil) {
+				return
+			}
+		}
+	}
+}
@@ -3,6 +3,8 @@ package firefox
 import (
 	"encoding/json"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -59,20 +61,47 @@ func parseContainersJSON(r io.Reader) (map[int]string, error) {
 
 	contMap := make(map[int]string)
 	for _, cont := range conts.Identities {
-		var name string
-		if cont.Name != nil {
-			name = *cont.Name
-			if strings.HasPrefix(name, `userContextIdInternal.`) {
-				name = ``
-			}
+		contMap[cont.UserContextID] = containerName(cont.Name, cont.L10nID)
+	}
+	return contMap, nil
+}
+
+// PublicContainerNames returns the names of the public containers in containers.json
+// in the order of the file.
+//
+// Firefox registers the public containers in this order as WebDriver BiDi user contexts.
+func PublicContainerNames(profileDir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(profileDir, `containers.json`))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	conts := &containers{}
+	if err := json.NewDecoder(f).Decode(conts); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, cont := range conts.Identities {
+		if cont.Public {
+			names = append(names, containerName(cont.Name, cont.L10nID))
 		}
-		// fall back to l10nId for default containers (Personal, Work, Banking, Shopping)
-		if name == `` && cont.L10nID != nil {
-			name = defaultContainerLabels[*cont.L10nID]
+	}
+	return names, nil
+}
+
+func containerName(name, l10nID *string) string {
+	var n string
+	if name != nil {
+		n = *name
+		if strings.HasPrefix(n, `userContextIdInternal.`) {
+			n = ``
 		}
-		contMap[cont.UserContextID] = name
 	}
-	return contMap, nil
+	// fall back to l10nId for default containers (Personal, Work, Banking, Shopping)
+	if n == `` && l10nID != nil {
+		n = defaultContainerLabels[*l10nID]
+	}
+	return n
 }
 
 type containers struct {
@@ -90,6 +119,10 @@ type containers struct {
 	Version           int `json:"version"`
 }
 
+// AttrFirstPartyDomain is the name of the http.Cookie.Unparsed attribute
+// holding the firstPartyDomain origin attribute of first-party isolation.
+const AttrFirstPartyDomain = `FirstPartyDomain`
+
 // parseOriginAttributes parses the originAttributes column from moz_cookies.
 //
 // Format: "^key1=value1&key2=value2" (leading ^ is stripped).
@@ -111,3 +144,45 @@ func parseOriginAttributes(s string) map[string]string {
 	}
 	return attrs
 }
+
+// parsePartitionKey converts the partitionKey origin attribute to the site form used by Chrome.
+//
+// "%28https%2Cexample.com%29" (URL-encoded "(https,example.com)") becomes "https://example.com",
+// an optional third element is the port.
+func parsePartitionKey(s string) string {
+	if unescaped, err := url.QueryUnescape(s); err == nil {
+		s = unescaped
+	}
+	inner, ok := strings.CutPrefix(s, `(`)
+	if !ok {
+		return s
+	}
+	inner, ok = strings.CutSuffix(inner, `)`)
+	if !ok {
+		return s
+	}
+	parts := strings.Split(inner, `,`)
+	if len(parts) < 2 {
+		return s
+	}
+	site := parts[0] + `://` + parts[1]
+	if len(parts) > 2 && len(parts[2]) > 0 {
+		site += `:` + parts[2]
+	}
+	return site
+}
+
+// sameSiteFromFirefox converts the nsICookie sameSite value of moz_cookies.
+//
+// 0 (SAMESITE_NONE) is used both for "SameSite=None" and cookies without the attribute,
+// it is left unset.
+func sameSiteFromFirefox(v int64) http.SameSite {
+	switch v {
+	case 1:
+		return http.SameSiteLaxMode
+	case 2:
+		return http.SameSiteStrictMode
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,56 @@
+package find
+
+import (
+	"iter"
+	"path/filepath"
+
+	"github.com/browserutils/kooky/internal/android"
+)
+
+// fenixPackages are the package names of Firefox for Android (Fenix),
+// the first one is the release channel.
+var fenixPackages = []string{
+	`org.mozilla.firefox`,
+	`org.mozilla.firefox_beta`,
+	`org.mozilla.fenix`, // Nightly
+	`org.mozilla.fennec_fdroid`,
+}
+
+// FindFenixProfiles yields the Firefox for Android (Fenix) profiles in the Android app data below root.
+// The profile name is the package name of the app, e.g. "org.mozilla.firefox_beta".
+func FindFenixProfiles(root string) iter.Seq2[Profile, error] {
+	return func(yield func(Profile, error) bool) {
+		for app := range android.Apps(root, fenixPackages...) {
+			name := app.Package
+			if len(app.User) > 0 {
+				name += ` (user ` + app.User + `)`
+			}
+			isDefaultApp := app.Package == fenixPackages[0] && len(app.User) == 0
+
+			mozDir := app.Path(`files`, `mozilla`)
+			profiles, err := FindProfilesInRoot(mozDir, `firefox`)
+			if err != nil {
+				// profiles.ini missing in partial dumps
+				dirs, _ := filepath.Glob(filepath.Join(mozDir, `*`, `cookies.sqlite`))
+				for _, dir := range dirs {
+					profiles = append(profiles, Profile{Path: filepath.Dir(dir), Browser: `firefox`})
+				}
+				if len(profiles) == 1 {
+					profiles[0].IsDefaultProfile = true
+				}
+			}
+			multiple := len(profiles) > 1
+			for _, p := range profiles {
+				if multiple {
+					p.Name = name + `/` + filepath.Base(p.Path)
+				} else {
+					p.Name = name
+				}
+				p.IsDefaultProfile = p.IsDefaultProfile && isDefaultApp
+				if !yield(p, nil) {
+					return
+				}
+			}
+		}
+	}
+}
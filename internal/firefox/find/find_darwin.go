@@ -18,3 +18,23 @@ func firefoxRoots(yield func(string, error) bool) {
 		return
 	}
 }
+
+func forkRoots(f *Fork) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		// "$HOME/Library/Application Support"
+		cfgDir, err := os.UserConfigDir()
+		if err != nil {
+			_ = yield(``, err)
+			return
+		}
+		if !yieldJoined(yield, cfgDir, f.Darwin) {
+			return
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			_ = yield(``, err)
+			return
+		}
+		yieldJoined(yield, home, f.Portable)
+	}
+}
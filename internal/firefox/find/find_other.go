@@ -3,3 +3,7 @@
 package find
 
 func windowsFirefoxRoots(yield func(string, error) bool) {}
+
+func windowsForkRoots(f *Fork) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {}
+}
@@ -5,3 +5,7 @@ package find
 import "errors"
 
 func firefoxRoots(yield func(string, error) bool) { yield(``, errors.New(`not implemented`)) }
+
+func forkRoots(f *Fork) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) { yield(``, errors.New(`not implemented`)) }
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/browserutils/kooky/internal/sandbox"
 	"github.com/browserutils/kooky/internal/windowsx"
 )
 
@@ -16,7 +17,7 @@ func firefoxRoots(yield func(string, error) bool) {
 		return
 	}
 	// Ubuntu 21.10 (snap)
-	if !yield(filepath.Join(home, `snap`, `firefox`, `common`, `.mozilla`, `firefox`), nil) {
+	if !yield(sandbox.SnapDir(home, `firefox`, `common`, `.mozilla`, `firefox`), nil) {
 		return
 	}
 	if !yield(filepath.Join(home, `.mozilla`, `firefox`), nil) {
@@ -26,6 +27,10 @@ func firefoxRoots(yield func(string, error) bool) {
 	if !yield(filepath.Join(home, `.mozilla`, `firefox-esr`), nil) {
 		return
 	}
+	// Flathub
+	if !yield(sandbox.FlatpakDir(home, `org.mozilla.firefox`, `.mozilla`, `firefox`), nil) {
+		return
+	}
 	// on WSL Linux add Windows paths
 	if !windowsx.IsWSL() {
 		return
@@ -36,3 +41,28 @@ func firefoxRoots(yield func(string, error) bool) {
 		}
 	}
 }
+
+func forkRoots(f *Fork) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			_ = yield(``, err)
+			return
+		}
+		if !yieldJoined(yield, home, f.Linux) || !yieldJoined(yield, home, f.Portable) {
+			return
+		}
+		if len(f.Flatpak) > 0 && !yieldJoined(yield, sandbox.FlatpakDir(home, f.Flatpak), f.Linux) {
+			return
+		}
+		// on WSL Linux add Windows paths
+		if !windowsx.IsWSL() {
+			return
+		}
+		for r, err := range windowsForkRoots(f) {
+			if !yield(r, err) {
+				return
+			}
+		}
+	}
+}
@@ -19,3 +19,24 @@ func windowsFirefoxRoots(yield func(string, error) bool) {
 	}
 	_ = yield(filepath.Join(appData, `Mozilla`, `Firefox`), nil)
 }
+
+func windowsForkRoots(f *Fork) func(yield func(string, error) bool) {
+	return func(yield func(string, error) bool) {
+		// "%AppData%"
+		appData, err := windowsx.AppData()
+		if err != nil {
+			_ = yield(``, err)
+			return
+		}
+		if !yieldJoined(yield, appData, f.Windows) {
+			return
+		}
+		// "%UserProfile%"
+		userProfile, err := windowsx.UserProfile()
+		if err != nil {
+			_ = yield(``, err)
+			return
+		}
+		yieldJoined(yield, userProfile, f.Portable)
+	}
+}
@@ -2,4 +2,7 @@
 
 package find
 
-var firefoxRoots = windowsFirefoxRoots
+var (
+	firefoxRoots = windowsFirefoxRoots
+	forkRoots    = windowsForkRoots
+)
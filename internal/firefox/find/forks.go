@@ -0,0 +1,122 @@
+package find
+
+import (
+	"iter"
+	"path/filepath"
+	"slices"
+)
+
+// Fork describes a Gecko (or Goanna) based browser storing its profiles like Firefox does.
+type Fork struct {
+	Browser string // kooky browser name, e.g. "librewolf"
+
+	// profiles.ini directories relative to the OS specific base directory
+	Linux   [][]string // "$HOME"
+	Darwin  [][]string // "$HOME/Library/Application Support"
+	Windows [][]string // "%AppData%"
+
+	Flatpak string // Flatpak app ID, the Linux directories are also searched in its app directory
+
+	// profiles.ini directories of extracted browser bundles relative to the user's home directory on all OSes
+	Portable [][]string
+}
+
+// torData is the profiles.ini directory within a Tor Browser based bundle
+var torData = []string{`Browser`, `TorBrowser`, `Data`, `Browser`}
+
+func bundle(dir ...string) []string { return append(dir, torData...) }
+
+// Forks lists the Firefox forks without their own browser specific handling.
+var Forks = []*Fork{
+	{
+		Browser: `floorp`,
+		Linux:   [][]string{{`.floorp`}},
+		Darwin:  [][]string{{`Floorp`}},
+		Windows: [][]string{{`Floorp`}},
+		Flatpak: `one.ablaze.floorp`,
+	},
+	{
+		Browser: `librewolf`,
+		Linux:   [][]string{{`.librewolf`}},
+		Darwin:  [][]string{{`librewolf`}},
+		Windows: [][]string{{`librewolf`}},
+		Flatpak: `io.gitlab.librewolf-community`,
+	},
+	{
+		Browser: `mullvad`,
+		Linux:   [][]string{{`.mullvad-browser`}},
+		Darwin:  [][]string{{`MullvadBrowser-Data`, `Browser`}},
+		Windows: [][]string{{`Mullvad`, `MullvadBrowser`}},
+		Portable: [][]string{
+			bundle(`mullvad-browser`),
+			bundle(`Desktop`, `Mullvad Browser`),
+		},
+	},
+	{
+		Browser: `palemoon`,
+		Linux:   [][]string{{`.moonchild productions`, `pale moon`}},
+		Darwin:  [][]string{{`Pale Moon`}},
+		Windows: [][]string{{`Moonchild Productions`, `Pale Moon`}},
+	},
+	{
+		Browser: `seamonkey`,
+		Linux:   [][]string{{`.mozilla`, `seamonkey`}},
+		Darwin:  [][]string{{`SeaMonkey`}},
+		Windows: [][]string{{`Mozilla`, `SeaMonkey`}},
+	},
+	{
+		Browser: `tor`,
+		// torbrowser-launcher
+		Linux:  [][]string{bundle(`.local`, `share`, `torbrowser`, `tbb`, `x86_64`, `tor-browser`)},
+		Darwin: [][]string{{`TorBrowser-Data`, `Browser`}},
+		Portable: [][]string{
+			bundle(`tor-browser`),
+			bundle(`tor-browser_en-US`),
+			bundle(`Desktop`, `Tor Browser`),
+		},
+	},
+	{
+		Browser: `waterfox`,
+		Linux:   [][]string{{`.waterfox`}},
+		Darwin:  [][]string{{`Waterfox`}},
+		Windows: [][]string{{`Waterfox`}},
+	},
+	{
+		Browser: `zen`,
+		Linux:   [][]string{{`.zen`}},
+		Darwin:  [][]string{{`zen`}},
+		Windows: [][]string{{`zen`}},
+		Flatpak: `app.zen_browser.zen`,
+	},
+}
+
+// LookupFork() returns the Fork with the browser name or nil.
+func LookupFork(browser string) *Fork {
+	i := slices.IndexFunc(Forks, func(f *Fork) bool { return f.Browser == browser })
+	if i < 0 {
+		return nil
+	}
+	return Forks[i]
+}
+
+// Roots() yields the profiles.ini directories of the fork on the current OS.
+func (f *Fork) Roots(yield func(string, error) bool) {
+	if f == nil {
+		return
+	}
+	forkRoots(f)(yield)
+}
+
+func (f *Fork) FindProfiles() iter.Seq2[Profile, error] {
+	return FindProfiles(f.Roots, f.Browser)
+}
+
+// yieldJoined yields the paths joined to base and reports whether to continue.
+func yieldJoined(yield func(string, error) bool, base string, paths [][]string) bool {
+	for _, pathParts := range paths {
+		if !yield(filepath.Join(append([]string{base}, pathParts...)...), nil) {
+			return false
+		}
+	}
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -42,19 +43,17 @@ func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
 			}
 
 			// Domain
-			if baseDomain := row.ValueOrFallback(`baseDomain`, nil); baseDomain == nil {
-				if host, err := row.String(`host`); err != nil {
-					return err
-				} else {
-					cookie.Domain = host
-				}
-			} else {
-				// handle databases prior v78 ESR
+			// databases prior v78 ESR also have a "baseDomain" column with the registrable domain
+			if host, err := row.String(`host`); err == nil {
+				cookie.Domain = host
+			} else if baseDomain := row.ValueOrFallback(`baseDomain`, nil); baseDomain != nil {
 				var ok bool
 				cookie.Domain, ok = baseDomain.(string)
 				if !ok {
 					return fmt.Errorf("got unexpected value for baseDomain %v (type %[1]T)", baseDomain)
 				}
+			} else {
+				return err
 			}
 
 			// Path
@@ -73,6 +72,9 @@ func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
 			// Creation
 			if creationTime, err := row.Int64(`creationTime`); err == nil {
 				cookie.Creation = time.UnixMicro(creationTime)
+			} else if row.ValueOrFallback(`creationTime`, nil) == nil && rowId != nil {
+				// Firefox 3.0 (and SeaMonkey 2.0) used the creation time in microseconds as id
+				cookie.Creation = time.UnixMicro(*rowId)
 			} else {
 				return err
 			}
@@ -83,10 +85,12 @@ func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
 				return err
 			}
 
-			// HttpOnly
-			cookie.HttpOnly, err = row.Bool(`isHttpOnly`)
-			if err != nil {
-				return err
+			// HttpOnly (column missing in Firefox 2 era databases)
+			if row.ValueOrFallback(`isHttpOnly`, nil) != nil {
+				cookie.HttpOnly, err = row.Bool(`isHttpOnly`)
+				if err != nil {
+					return err
+				}
 			}
 
 			// Container and Partitioned
@@ -101,11 +105,25 @@ func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
 						}
 					}
 				}
-				if _, ok := attrs[`partitionKey`]; ok {
+				if partitionKey, ok := attrs[`partitionKey`]; ok {
 					cookie.Partitioned = true
+					cookie.PartitionKey = parsePartitionKey(partitionKey)
+				}
+				if firstParty, ok := attrs[`firstPartyDomain`]; ok && len(firstParty) > 0 {
+					// first-party isolation (Tor Browser, Mullvad Browser, privacy.firstparty.isolate)
+					// is not CHIPS partitioning, no http.Cookie field for it
+					if unescaped, err := url.QueryUnescape(firstParty); err == nil {
+						firstParty = unescaped
+					}
+					cookie.Unparsed = append(cookie.Unparsed, AttrFirstPartyDomain+`=`+firstParty)
 				}
 			}
 
+			// SameSite (column missing in older databases)
+			if sameSite, err := row.Int64(`sameSite`); err == nil {
+				cookie.SameSite = sameSiteFromFirefox(sameSite)
+			}
+
 			cookie.Browser = s
 
 			if !iterx.CookieFilterYield(context.Background(), &cookie, nil, yield, filters...) {
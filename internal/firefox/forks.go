@@ -0,0 +1,46 @@
+package firefox
+
+import (
+	"errors"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/firefox/find"
+)
+
+// ForkCookieStore() returns the cookie store of a Firefox fork.
+func ForkCookieStore(f *find.Fork, filename string, filters ...kooky.Filter) (*cookies.CookieJar, error) {
+	if f == nil {
+		return nil, errors.New(`fork is nil`)
+	}
+	s := &CookieStore{}
+	s.FileNameStr = filename
+	s.BrowserStr = f.Browser
+
+	return cookies.NewCookieJar(s, filters...), nil
+}
+
+// ForkFinder finds the cookie stores and profiles of a Firefox fork
+// at the locations listed in its find.Fork.
+type ForkFinder struct {
+	Fork *find.Fork
+}
+
+var (
+	_ kooky.CookieStoreFinder = (*ForkFinder)(nil)
+	_ kooky.ProfileFinder     = (*ForkFinder)(nil)
+)
+
+func (f *ForkFinder) FindCookieStores() kooky.CookieStoreSeq {
+	if f == nil || f.Fork == nil {
+		return func(yield func(kooky.CookieStore, error) bool) { _ = yield(nil, errors.New(`fork is nil`)) }
+	}
+	return CookieStoresForProfiles(f.Fork.FindProfiles())
+}
+
+func (f *ForkFinder) FindProfiles() kooky.ProfileSeq {
+	if f == nil || f.Fork == nil {
+		return func(yield func(*kooky.Profile, error) bool) { _ = yield(nil, errors.New(`fork is nil`)) }
+	}
+	return KookyProfiles(f.Fork.FindProfiles())
+}
@@ -0,0 +1,60 @@
+package firefox
+
+import (
+	"encoding/json"
+	"iter"
+	"os"
+	"path/filepath"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/firefox/find"
+)
+
+// KookyProfiles converts profiles listed in profiles.ini files to kooky.Profile.
+func KookyProfiles(profiles iter.Seq2[find.Profile, error]) kooky.ProfileSeq {
+	return func(yield func(*kooky.Profile, error) bool) {
+		if profiles == nil {
+			return
+		}
+		for p, err := range profiles {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			kp := &kooky.Profile{
+				Browser:      p.Browser,
+				Name:         p.Name,
+				Dir:          p.Path,
+				IsDefault:    p.IsDefaultProfile,
+				DisplayName:  p.Name,
+				AccountEmail: accountEmail(p.Path),
+			}
+			// prefs.js is rewritten when the browser shuts down
+			if fi, err := os.Stat(filepath.Join(p.Path, `prefs.js`)); err == nil {
+				kp.LastUsed = fi.ModTime()
+			}
+			if !yield(kp, nil) {
+				return
+			}
+		}
+	}
+}
+
+// accountEmail returns the email address of the Firefox account signed in to the profile.
+func accountEmail(profileDir string) string {
+	b, err := os.ReadFile(filepath.Join(profileDir, `signedInUser.json`))
+	if err != nil {
+		return ``
+	}
+	var signedInUser struct {
+		AccountData struct {
+			Email string `json:"email"`
+		} `json:"accountData"`
+	}
+	if err := json.Unmarshal(b, &signedInUser); err != nil {
+		return ``
+	}
+	return signedInUser.AccountData.Email
+}
@@ -168,6 +168,7 @@ func (s *SessionCookieStore) TraverseCookies(filters ...kooky.Filter) kooky.Cook
 			// CHIPS partitioned cookie
 			if len(sc.OriginAttributes.PartitionKey) > 0 {
 				cookie.Partitioned = true
+				cookie.PartitionKey = parsePartitionKey(sc.OriginAttributes.PartitionKey)
 			}
 
 			if !iterx.CookieFilterYield(context.Background(), cookie, nil, yield, filters...) {
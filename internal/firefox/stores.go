@@ -15,9 +15,13 @@ type storeOrErr struct {
 	err   error
 }
 
+// maximum number of profiles processed in parallel
+const maxParallelProfiles = 8
+
 // CookieStoresForProfiles returns all cookie stores (SQLite + session) for
 // the given profiles. Each profile is processed in its own goroutine to
 // avoid blocking if a profile path is on a slow filesystem (e.g. network share).
+// At most maxParallelProfiles goroutines run at once.
 // Errors from the profile iterator are forwarded to the caller.
 func CookieStoresForProfiles(profiles iter.Seq2[find.Profile, error]) kooky.CookieStoreSeq {
 	if profiles == nil {
@@ -27,12 +31,15 @@ func CookieStoresForProfiles(profiles iter.Seq2[find.Profile, error]) kooky.Cook
 		ch := make(chan storeOrErr)
 		quit := make(chan struct{})
 
+		sem := make(chan struct{}, maxParallelProfiles)
+
 		var wg sync.WaitGroup
 		// feed goroutine: reads profiles, spawns per-profile goroutines
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			var inner sync.WaitGroup
+			defer inner.Wait()
 			for p, err := range profiles {
 				select {
 				case <-quit:
@@ -47,9 +54,15 @@ func CookieStoresForProfiles(profiles iter.Seq2[find.Profile, error]) kooky.Cook
 					}
 					continue
 				}
+				select {
+				case sem <- struct{}{}:
+				case <-quit:
+					return
+				}
 				inner.Add(1)
 				go func(p find.Profile) {
 					defer inner.Done()
+					defer func() { <-sem }()
 					for _, st := range cookieStoresForProfile(p) {
 						select {
 						case ch <- storeOrErr{store: st}:
@@ -59,7 +72,6 @@ func CookieStoresForProfiles(profiles iter.Seq2[find.Profile, error]) kooky.Cook
 					}
 				}(p)
 			}
-			inner.Wait()
 		}()
 		go func() {
 			wg.Wait()
@@ -0,0 +1,188 @@
+package firefox
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/sqlitex"
+)
+
+var (
+	_ kooky.CookieWriter       = (*CookieStore)(nil)
+	_ kooky.CapabilityReporter = (*CookieStore)(nil)
+)
+
+// Capabilities reports the properties of cookies.sqlite.
+// Session cookies are kept in the session store files instead.
+func (s *CookieStore) Capabilities() kooky.StoreCapabilities {
+	return kooky.StoreCapabilities{Writable: sqlitex.Enabled(), Containers: true, Partitions: true, PersistentOnly: true}
+}
+
+// WriteCookies writes the cookies to cookies.sqlite.
+// The browser has to be closed, it locks the database while running.
+// Writing has to be enabled by importing github.com/browserutils/kooky/sqlitewrite.
+//
+// Containers are looked up by name in containers.json.
+func (s *CookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	if err := s.Open(); err != nil {
+		return err
+	}
+	s.initContainersMap()
+	if err := s.Close(); err != nil {
+		return err
+	}
+
+	db, err := sqlitex.Open(s.FileNameStr)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	table, err := sqlitex.ReadTable(tx, `moz_cookies`)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, c := range kookies {
+		if c == nil || len(c.Domain) == 0 {
+			continue
+		}
+		if err := s.writeCookie(tx, table, c, now); err != nil {
+			return fmt.Errorf("cookie %q: %w", c.Name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *CookieStore) writeCookie(tx *sql.Tx, table *sqlitex.Table, c *kooky.Cookie, now time.Time) error {
+	origAttrs, err := s.originAttributes(c)
+	if err != nil {
+		return err
+	}
+
+	del := `DELETE FROM moz_cookies WHERE host = ? AND name = ? AND path = ?`
+	args := []any{c.Domain, c.Name, c.Path}
+	if table.Has(`originAttributes`) {
+		del += ` AND originAttributes = ?`
+		args = append(args, origAttrs)
+	}
+	if _, err := tx.Exec(del, args...); err != nil {
+		return err
+	}
+	if c.MaxAge < 0 || (!c.Expires.IsZero() && c.Expires.Before(now)) {
+		// deletion
+		return nil
+	}
+	if c.Expires.IsZero() {
+		return errors.New(`session cookies are not stored in cookies.sqlite`)
+	}
+
+	creation := c.Creation
+	if creation.IsZero() {
+		creation = now
+	}
+	baseDomain, err := publicsuffix.EffectiveTLDPlusOne(strings.TrimPrefix(c.Domain, `.`))
+	if err != nil {
+		baseDomain = strings.TrimPrefix(c.Domain, `.`)
+	}
+	sameSite := sameSiteToFirefox(c.SameSite)
+
+	return table.Insert(tx, map[string]any{
+		`baseDomain`:                baseDomain, // before Firefox 78
+		`originAttributes`:          origAttrs,
+		`name`:                      c.Name,
+		`value`:                     c.Value,
+		`host`:                      c.Domain,
+		`path`:                      c.Path,
+		`expiry`:                    c.Expires.Unix(),
+		`lastAccessed`:              now.UnixMicro(),
+		`creationTime`:              creation.UnixMicro(),
+		`isSecure`:                  c.Secure,
+		`isHttpOnly`:                c.HttpOnly,
+		`inBrowserElement`:          0,
+		`sameSite`:                  sameSite,
+		`rawSameSite`:               sameSite,
+		`isPartitionedAttributeSet`: c.Partitioned,
+	})
+}
+
+// originAttributes is the inverse of parseOriginAttributes for containers, first-party isolation
+// and partitioned cookies, in the order Firefox uses.
+func (s *CookieStore) originAttributes(c *kooky.Cookie) (string, error) {
+	var attrs []string
+	if len(c.Container) > 0 {
+		ucid, err := s.userContextID(c.Container)
+		if err != nil {
+			return ``, err
+		}
+		attrs = append(attrs, `userContextId=`+strconv.Itoa(ucid))
+	}
+	for _, attr := range c.Unparsed {
+		if firstParty, ok := strings.CutPrefix(attr, AttrFirstPartyDomain+`=`); ok && len(firstParty) > 0 {
+			attrs = append(attrs, `firstPartyDomain=`+url.QueryEscape(firstParty))
+		}
+	}
+	if c.Partitioned && len(c.PartitionKey) > 0 {
+		attrs = append(attrs, `partitionKey=`+formatPartitionKey(c.PartitionKey))
+	}
+	if len(attrs) == 0 {
+		return ``, nil
+	}
+	return `^` + strings.Join(attrs, `&`), nil
+}
+
+// userContextID returns the ID of the container with the name from containers.json.
+// Numeric IDs are accepted as well.
+func (s *CookieStore) userContextID(container string) (int, error) {
+	for id, name := range s.Containers {
+		if name == container {
+			return id, nil
+		}
+	}
+	if id, err := strconv.Atoi(container); err == nil && id > 0 {
+		return id, nil
+	}
+	return 0, fmt.Errorf("unknown container %q", container)
+}
+
+// formatPartitionKey is the inverse of parsePartitionKey:
+// "https://example.com" becomes "%28https%2Cexample.com%29".
+func formatPartitionKey(site string) string {
+	scheme, host, ok := strings.Cut(site, `://`)
+	if !ok {
+		return url.QueryEscape(site)
+	}
+	parts := []string{scheme, host}
+	if h, port, ok := strings.Cut(host, `:`); ok {
+		parts = []string{scheme, h, port}
+	}
+	return url.QueryEscape(`(` + strings.Join(parts, `,`) + `)`)
+}
+
+// sameSiteToFirefox is the inverse of sameSiteFromFirefox.
+func sameSiteToFirefox(s http.SameSite) int {
+	switch s {
+	case http.SameSiteLaxMode:
+		return 1
+	case http.SameSiteStrictMode:
+		return 2
+	default:
+		return 0
+	}
+}
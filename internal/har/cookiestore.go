@@ -0,0 +1,11 @@
+package har
+
+import (
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+type CookieStore struct {
+	cookies.DefaultCookieStore
+}
+
+var _ cookies.CookieStore = (*CookieStore)(nil)
@@ -0,0 +1,155 @@
+package har
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/iterx"
+)
+
+// HAR 1.2
+// http://www.softwareishard.com/blog/har-12-spec/
+
+type archive struct {
+	Log struct {
+		Entries []entry `json:"entries"`
+	} `json:"log"`
+}
+
+type entry struct {
+	StartedDateTime time.Time `json:"startedDateTime"`
+	Request         struct {
+		URL     string      `json:"url"`
+		Cookies []harCookie `json:"cookies"`
+	} `json:"request"`
+	Response struct {
+		Cookies []harCookie `json:"cookies"`
+		Headers []header    `json:"headers"`
+	} `json:"response"`
+}
+
+type harCookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	Path     string  `json:"path"`
+	Domain   string  `json:"domain"`
+	Expires  *string `json:"expires"` // ISO 8601
+	HTTPOnly bool    `json:"httpOnly"`
+	Secure   bool    `json:"secure"`
+	SameSite string  `json:"sameSite"` // not part of HAR 1.2, written by Chrome
+}
+
+type header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	if s == nil {
+		return iterx.ErrCookieSeq(errors.New(`cookie store is nil`))
+	}
+	if err := s.Open(); err != nil {
+		return iterx.ErrCookieSeq(err)
+	}
+	if s.File == nil {
+		return iterx.ErrCookieSeq(errors.New(`file is nil`))
+	}
+	return TraverseCookies(s.File, s, filters...)
+}
+
+// TraverseCookies replays the entries of a HAR archive in the order of their start time through the cookie storage model of RFC 6265
+// and yields the cookies stored at the end.
+//
+// Set-Cookie response headers are used if present, the response cookie list otherwise.
+// Cookies sent with requests which weren't set by a previous response (e.g. set before the recording started)
+// are added as host-only session cookies of the request host.
+func TraverseCookies(r io.Reader, bi kooky.BrowserInfo, filters ...kooky.Filter) kooky.CookieSeq {
+	return func(yield func(*kooky.Cookie, error) bool) {
+		var har archive
+		if err := json.NewDecoder(r).Decode(&har); err != nil {
+			yield(nil, err)
+			return
+		}
+		// replay in chronological order, entries of parallel requests might be out of order
+		slices.SortStableFunc(har.Log.Entries, func(a, b entry) int { return a.StartedDateTime.Compare(b.StartedDateTime) })
+		j := newJar()
+		var last time.Time
+		for _, e := range har.Log.Entries {
+			u, err := url.Parse(e.Request.URL)
+			if err != nil || len(u.Hostname()) == 0 {
+				continue
+			}
+			now := e.StartedDateTime
+			if now.After(last) {
+				last = now
+			}
+			for _, c := range e.Request.Cookies {
+				j.addRequestCookie(u, c.Name, c.Value, now)
+			}
+
+			var setCookies []*http.Cookie
+			for _, h := range e.Response.Headers {
+				if http.CanonicalHeaderKey(h.Name) != `Set-Cookie` {
+					continue
+				}
+				// multiple cookies might be joined by newlines
+				for line := range strings.Lines(h.Value) {
+					line = strings.TrimSpace(line)
+					if c, err := http.ParseSetCookie(line); err == nil {
+						setCookies = append(setCookies, c)
+					}
+				}
+			}
+			if len(setCookies) == 0 {
+				for _, c := range e.Response.Cookies {
+					setCookies = append(setCookies, c.httpCookie())
+				}
+			}
+			for _, c := range setCookies {
+				j.setCookie(u, c, now)
+			}
+		}
+
+		for _, cookie := range j.cookies(last) {
+			cookie.Browser = bi
+			if !iterx.CookieFilterYield(context.Background(), cookie, nil, yield, filters...) {
+				return
+			}
+		}
+	}
+}
+
+// httpCookie converts the cookie of the HAR response cookie list.
+// Its domain and path are treated like the attributes of a Set-Cookie header.
+func (c harCookie) httpCookie() *http.Cookie {
+	hc := &http.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Path:     c.Path,
+		Domain:   c.Domain,
+		HttpOnly: c.HTTPOnly,
+		Secure:   c.Secure,
+	}
+	if c.Expires != nil {
+		if exp, err := time.Parse(time.RFC3339, *c.Expires); err == nil {
+			hc.Expires = exp
+		}
+	}
+	switch c.SameSite {
+	case `Strict`, `strict`:
+		hc.SameSite = http.SameSiteStrictMode
+	case `Lax`, `lax`:
+		hc.SameSite = http.SameSiteLaxMode
+	case `None`, `none`:
+		hc.SameSite = http.SameSiteNoneMode
+	}
+	return hc
+}
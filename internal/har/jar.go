@@ -0,0 +1,130 @@
+package har
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+// jar implements the storage model of RFC 6265 section 5.3
+// with the time of the HAR entries as current time.
+type jar struct {
+	entries []*jarEntry // in creation order
+}
+
+type jarEntry struct {
+	cookie   *kooky.Cookie
+	hostOnly bool
+	domain   string // without leading dot
+}
+
+func newJar() *jar { return &jar{} }
+
+func (j *jar) find(name, domain, path string, hostOnly bool) int {
+	for i, e := range j.entries {
+		if e.cookie.Name == name && e.domain == domain && e.cookie.Path == path && e.hostOnly == hostOnly {
+			return i
+		}
+	}
+	return -1
+}
+
+// setCookie stores the cookie received in a response from u at time now.
+func (j *jar) setCookie(u *url.URL, c *http.Cookie, now time.Time) {
+	if c == nil || len(c.Name) == 0 {
+		return
+	}
+	cookie := cookies.ResponseCookie(u, c, now, nil)
+	if cookie == nil {
+		return
+	}
+	cookie.RawExpires = ``
+	cookie.Raw = ``
+	// the domain of host-only cookies has no leading dot
+	domain, hasDot := strings.CutPrefix(cookie.Domain, `.`)
+	hostOnly := !hasDot
+
+	i := j.find(c.Name, domain, cookie.Path, hostOnly)
+	if i >= 0 {
+		// keep creation time of the replaced cookie
+		cookie.Creation = j.entries[i].cookie.Creation
+	}
+	if !cookie.Expires.IsZero() && !cookie.Expires.After(now) {
+		// expired: deletion
+		if i >= 0 {
+			j.entries = append(j.entries[:i], j.entries[i+1:]...)
+		}
+		return
+	}
+	e := &jarEntry{cookie: cookie, hostOnly: hostOnly, domain: domain}
+	if i >= 0 {
+		j.entries[i] = e
+		return
+	}
+	j.entries = append(j.entries, e)
+}
+
+// addRequestCookie adds a cookie sent to u unless a stored cookie with that name would have been sent.
+func (j *jar) addRequestCookie(u *url.URL, name, value string, now time.Time) {
+	if len(name) == 0 {
+		return
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, e := range j.entries {
+		if e.cookie.Name != name {
+			continue
+		}
+		if e.hostOnly && e.domain != host || !e.hostOnly && !domainMatch(host, e.domain) {
+			continue
+		}
+		if pathMatch(u.Path, e.cookie.Path) {
+			return
+		}
+	}
+	cookie := &kooky.Cookie{Creation: now}
+	cookie.Name = name
+	cookie.Value = value
+	cookie.Domain = host
+	cookie.Path = `/`
+	cookie.Secure = u.Scheme == `https`
+	j.entries = append(j.entries, &jarEntry{cookie: cookie, hostOnly: true, domain: host})
+}
+
+// cookies returns the cookies not expired at time now.
+func (j *jar) cookies(now time.Time) []*kooky.Cookie {
+	var ret []*kooky.Cookie
+	for _, e := range j.entries {
+		if !e.cookie.Expires.IsZero() && !e.cookie.Expires.After(now) {
+			continue
+		}
+		ret = append(ret, e.cookie)
+	}
+	return ret
+}
+
+// domainMatch implements RFC 6265 section 5.1.3.
+func domainMatch(host, domain string) bool {
+	if host == domain {
+		return true
+	}
+	return strings.HasSuffix(host, `.`+domain) && net.ParseIP(host) == nil
+}
+
+// pathMatch implements RFC 6265 section 5.1.4.
+func pathMatch(requestPath, cookiePath string) bool {
+	if len(requestPath) == 0 {
+		requestPath = `/`
+	}
+	if requestPath == cookiePath {
+		return true
+	}
+	if !strings.HasPrefix(requestPath, cookiePath) {
+		return false
+	}
+	return strings.HasSuffix(cookiePath, `/`) || requestPath[len(cookiePath)] == '/'
+}
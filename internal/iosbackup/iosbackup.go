@@ -0,0 +1,151 @@
+// Package iosbackup resolves the files of an unencrypted iTunes/Finder backup of an iOS device.
+//
+// The files of a backup are stored under the SHA-1 hash of "<domain>-<relativePath>"
+// and listed in the SQLite database Manifest.db (iOS 10+).
+package iosbackup
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-sqlite/sqlite3"
+
+	"github.com/browserutils/kooky/internal/utils"
+)
+
+const manifestFile = `Manifest.db`
+
+// File is a file listed in Manifest.db.
+type File struct {
+	ID           string // SHA-1 file name within the backup
+	Domain       string // e.g. "HomeDomain", "AppDomain-com.example.app"
+	RelativePath string // path relative to the domain root, e.g. "Library/Cookies/Cookies.binarycookies"
+	Path         string // path of the file within the backup directory
+}
+
+// BundleID() returns the bundle ID of an app domain ("AppDomain-<bundleID>",
+// "AppDomainGroup-<groupID>", "AppDomainPlugin-<bundleID>") or an empty string.
+func (f *File) BundleID() string {
+	if f == nil {
+		return ``
+	}
+	for _, prefix := range []string{`AppDomain-`, `AppDomainGroup-`, `AppDomainPlugin-`} {
+		if id, ok := strings.CutPrefix(f.Domain, prefix); ok {
+			return id
+		}
+	}
+	return ``
+}
+
+// IsBackup() reports whether dir contains a Manifest.db.
+func IsBackup(dir string) bool {
+	fi, err := os.Stat(filepath.Join(dir, manifestFile))
+	return err == nil && !fi.IsDir()
+}
+
+// Backups() yields dir if it is a backup, otherwise the backups in its subdirectories
+// like in the "MobileSync/Backup/<device UDID>" directory.
+func Backups(dir string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if IsBackup(dir) {
+			_ = yield(dir)
+			return
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, e := range entries {
+			if sub := filepath.Join(dir, e.Name()); e.IsDir() && IsBackup(sub) {
+				if !yield(sub) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Files() yields the regular files listed in the Manifest.db of backupDir
+// for which match returns true.
+func Files(backupDir string, match func(domain, relativePath string) bool) iter.Seq2[*File, error] {
+	return func(yield func(*File, error) bool) {
+		manifestPath := filepath.Join(backupDir, manifestFile)
+		f, err := utils.OpenFile(manifestPath)
+		if err != nil {
+			_ = yield(nil, err)
+			return
+		}
+		defer f.Close()
+		db, err := sqlite3.OpenFrom(f)
+		if err != nil {
+			_ = yield(nil, fmt.Errorf("%s: %w (encrypted backups are not supported)", manifestPath, err))
+			return
+		}
+		defer db.Close()
+
+		var files []*File
+		err = utils.VisitTableRows(db, `Files`, map[string]string{}, func(rowID *int64, row utils.TableRow) error {
+			// 1: file, 2: directory, 4: symbolic link
+			if flags, err := row.Int64(`flags`); err == nil && flags != 1 {
+				return nil
+			}
+			domain, err := row.String(`domain`)
+			if err != nil {
+				return err
+			}
+			relativePath, err := row.String(`relativePath`)
+			if err != nil {
+				return err
+			}
+			if match != nil && !match(domain, relativePath) {
+				return nil
+			}
+			id, err := row.String(`fileID`)
+			if err != nil {
+				return err
+			}
+			files = append(files, &File{
+				ID:           id,
+				Domain:       domain,
+				RelativePath: relativePath,
+				Path:         filePath(backupDir, id),
+			})
+			return nil
+		})
+		if err != nil {
+			_ = yield(nil, fmt.Errorf("%s: %w", manifestPath, err))
+			return
+		}
+		for _, file := range files {
+			if !yield(file, nil) {
+				return
+			}
+		}
+	}
+}
+
+// filePath() returns the location of a backup file, "<backupDir>/<first 2 hex digits>/<fileID>"
+// or the flat layout of older backups.
+func filePath(backupDir, id string) string {
+	if len(id) > 2 {
+		p := filepath.Join(backupDir, id[:2], id)
+		if _, err := os.Stat(p); !errors.Is(err, os.ErrNotExist) {
+			return p
+		}
+		if _, err := os.Stat(filepath.Join(backupDir, id)); err == nil {
+			return filepath.Join(backupDir, id)
+		}
+		return p
+	}
+	return filepath.Join(backupDir, id)
+}
+
+// IsCookieFile() reports whether relativePath is a WebKit cookie file below "Library/Cookies".
+func IsCookieFile(_, relativePath string) bool {
+	return path.Dir(relativePath) == `Library/Cookies` && strings.HasSuffix(relativePath, `.binarycookies`)
+}
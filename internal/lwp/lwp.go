@@ -0,0 +1,209 @@
+package lwp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/iterx"
+)
+
+// Python http.cookiejar.LWPCookieJar format
+// https://github.com/python/cpython/blob/3.12/Lib/http/cookiejar.py#L1866
+
+const (
+	header     = `#LWP-Cookies-2.0`
+	linePrefix = `Set-Cookie3:`
+	timeFormat = `2006-01-02 15:04:05Z`
+)
+
+var ErrNotLWP = errors.New(`not a LWP cookie file`)
+
+func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	if s == nil {
+		return iterx.ErrCookieSeq(errors.New(`cookie store is nil`))
+	}
+	if err := s.Open(); err != nil {
+		return iterx.ErrCookieSeq(err)
+	}
+	if s.File == nil {
+		return iterx.ErrCookieSeq(errors.New(`file is nil`))
+	}
+	return TraverseCookies(s.File, s, filters...)
+}
+
+// TraverseCookies parses a LWP cookie file.
+//
+// Attributes without a http.Cookie field (version, port, discard, comment, ...) are stored
+// in http.Cookie.Unparsed as "key=value" or "key" so that kooky.ExportCookiesLWP() can restore them.
+// HttpOnly and SameSite are stored in their http.Cookie fields.
+func TraverseCookies(r io.Reader, bi kooky.BrowserInfo, filters ...kooky.Filter) kooky.CookieSeq {
+	return func(yield func(*kooky.Cookie, error) bool) {
+		if r == nil {
+			yield(nil, errors.New(`file is nil`))
+			return
+		}
+		scanner := bufio.NewScanner(r)
+		if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), header) {
+			yield(nil, ErrNotLWP)
+			return
+		}
+		lineNr := 1
+		for scanner.Scan() {
+			lineNr++
+			line, ok := strings.CutPrefix(scanner.Text(), linePrefix)
+			if !ok {
+				// comments and empty lines
+				continue
+			}
+			cookie, err := parseLine(line)
+			if err != nil {
+				err = fmt.Errorf(`line %d: %w`, lineNr, err)
+			} else {
+				cookie.Browser = bi
+			}
+			if !iterx.CookieFilterYield(context.Background(), cookie, err, yield, filters...) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func parseLine(line string) (*kooky.Cookie, error) {
+	pairs := splitHeaderWords(line)
+	if len(pairs) == 0 {
+		return nil, errors.New(`no cookie`)
+	}
+	cookie := &kooky.Cookie{}
+	cookie.Name = pairs[0].key
+	if pairs[0].val != nil {
+		cookie.Value = *pairs[0].val
+	}
+	for _, p := range pairs[1:] {
+		key := p.key
+		switch lc := strings.ToLower(key); lc {
+		case `path`, `domain`, `expires`, `secure`:
+			key = lc
+		case `port`, `version`, `comment`, `commenturl`, `port_spec`, `path_spec`, `domain_dot`, `discard`:
+			// no Cookie fields
+			key = lc
+			if p.val == nil {
+				cookie.Unparsed = append(cookie.Unparsed, key)
+			} else {
+				cookie.Unparsed = append(cookie.Unparsed, key+`=`+*p.val)
+			}
+			continue
+		}
+		val := ``
+		if p.val != nil {
+			val = *p.val
+		}
+		switch key {
+		case `path`:
+			cookie.Path = val
+		case `domain`:
+			cookie.Domain = val
+		case `secure`:
+			cookie.Secure = true
+		case `expires`:
+			exp, err := time.Parse(timeFormat, val)
+			if err != nil {
+				return nil, fmt.Errorf(`cookie %q: expires: %w`, cookie.Name, err)
+			}
+			cookie.Expires = exp
+		case `HttpOnly`:
+			// stored as "HttpOnly=None"
+			cookie.HttpOnly = true
+		case `SameSite`:
+			// kept in the nonstandard attributes by http.cookiejar
+			switch strings.ToLower(val) {
+			case `none`:
+				cookie.SameSite = http.SameSiteNoneMode
+			case `lax`:
+				cookie.SameSite = http.SameSiteLaxMode
+			case `strict`:
+				cookie.SameSite = http.SameSiteStrictMode
+			default:
+				cookie.Unparsed = append(cookie.Unparsed, key+`=`+val)
+			}
+		default:
+			if p.val == nil {
+				cookie.Unparsed = append(cookie.Unparsed, key)
+			} else {
+				cookie.Unparsed = append(cookie.Unparsed, key+`=`+val)
+			}
+		}
+	}
+	return cookie, nil
+}
+
+type pair struct {
+	key string
+	val *string // nil for attributes without value
+}
+
+// splitHeaderWords splits `a=b; c="d \"e\""; f` like http.cookiejar.split_header_words().
+func splitHeaderWords(s string) []pair {
+	var ret []pair
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " \t;")
+		if len(s) == 0 {
+			break
+		}
+		i := strings.IndexAny(s, `=;`)
+		if i < 0 || s[i] == ';' {
+			if i < 0 {
+				i = len(s)
+			}
+			if key := strings.TrimSpace(s[:i]); len(key) > 0 {
+				ret = append(ret, pair{key: key})
+			}
+			s = s[i:]
+			continue
+		}
+		key := strings.TrimSpace(s[:i])
+		s = strings.TrimLeft(s[i+1:], " \t")
+		var val strings.Builder
+		if strings.HasPrefix(s, `"`) {
+			s = s[1:]
+			for len(s) > 0 {
+				c := s[0]
+				s = s[1:]
+				if c == '\\' && len(s) > 0 {
+					val.WriteByte(s[0])
+					s = s[1:]
+					continue
+				}
+				if c == '"' {
+					break
+				}
+				val.WriteByte(c)
+			}
+			// skip garbage up to the next attribute
+			if j := strings.IndexByte(s, ';'); j >= 0 {
+				s = s[j:]
+			} else {
+				s = ``
+			}
+		} else {
+			j := strings.IndexByte(s, ';')
+			if j < 0 {
+				j = len(s)
+			}
+			val.WriteString(strings.TrimSpace(s[:j]))
+			s = s[j:]
+		}
+		v := val.String()
+		ret = append(ret, pair{key: key, val: &v})
+	}
+	return ret
+}
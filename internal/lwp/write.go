@@ -0,0 +1,54 @@
+package lwp
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+var _ kooky.CookieWriter = (*CookieStore)(nil)
+
+// WriteCookies merges the cookies into the LWP file of the cookie store.
+func (s *CookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	// the file is replaced
+	if err := s.Close(); err != nil {
+		return err
+	}
+	return WriteFile(s.FileNameStr, kookies...)
+}
+
+// WriteFile merges the cookies into the LWP file filename.
+// The file is created if it does not exist.
+func WriteFile(filename string, updates ...*kooky.Cookie) error {
+	var existing []*kooky.Cookie
+	if f, err := os.Open(filename); err == nil {
+		// malformed lines are dropped
+		existing = TraverseCookies(f, nil).OnlyCookies().Collect(context.Background())
+		f.Close()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	merged := cookies.MergeCookies(existing, updates, time.Now())
+
+	// write to a temporary file first so that readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(filename), `.`+filepath.Base(filename)+`.*`)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	ctx := context.Background()
+	kooky.ExportCookiesLWP(ctx, tmp, kooky.FilterCookies(ctx, merged))
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
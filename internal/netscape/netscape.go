@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -42,7 +43,8 @@ func TraverseCookies(file io.Reader, bi kooky.BrowserInfo, filters ...kooky.Filt
 		// split line into fields
 		sp := strings.Split(line, "\t")
 		colCnt := 7
-		if l := len(sp); l != colCnt {
+		// libsoup (WebKitGTK) appends the SameSite policy as 8th field
+		if l := len(sp); l != colCnt && l != colCnt+1 {
 			if len(line) == 0 || strings.HasPrefix(line, `#`) {
 				// comment
 				return true // continue
@@ -89,7 +91,20 @@ func TraverseCookies(file io.Reader, bi kooky.BrowserInfo, filters ...kooky.Filt
 		cookie.Path = sp[2]
 		cookie.Name = sp[5]
 		cookie.Value = strings.TrimSpace(sp[6])
-		cookie.Expires = time.Unix(exp, 0)
+		if len(sp) > colCnt {
+			switch strings.TrimSpace(sp[7]) {
+			case `None`:
+				cookie.SameSite = http.SameSiteNoneMode
+			case `Lax`:
+				cookie.SameSite = http.SameSiteLaxMode
+			case `Strict`:
+				cookie.SameSite = http.SameSiteStrictMode
+			}
+		}
+		if exp != 0 {
+			// 0 marks session cookies
+			cookie.Expires = time.Unix(exp, 0)
+		}
 		cookie.Browser = bi
 
 		return iterx.CookieFilterYield(context.Background(), cookie, nil, yield, filters...)
@@ -0,0 +1,70 @@
+package netscape
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+var _ kooky.CookieWriter = (*CookieStore)(nil)
+
+func init() {
+	cookies.SidecarReader = ReadFile
+	cookies.SidecarWriter = WriteFile
+}
+
+// WriteCookies merges the cookies into the cookies.txt file of the cookie store.
+func (s *CookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	// the file is replaced
+	if err := s.Close(); err != nil {
+		return err
+	}
+	return WriteFile(s.FileNameStr, kookies...)
+}
+
+// ReadFile reads the netscape cookies.txt file filename.
+// A missing file has no cookies.
+func ReadFile(filename string) ([]*kooky.Cookie, error) {
+	f, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	seq, _ := TraverseCookies(f, nil)
+	// malformed lines are dropped
+	return seq.OnlyCookies().Collect(context.Background()), nil
+}
+
+// WriteFile merges the cookies into the netscape cookies.txt file filename.
+// The file is created if it does not exist.
+func WriteFile(filename string, updates ...*kooky.Cookie) error {
+	existing, err := ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	merged := cookies.MergeCookies(existing, updates, time.Now())
+
+	// write to a temporary file first so that readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(filename), `.`+filepath.Base(filename)+`.*`)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	kooky.ExportCookies(context.Background(), tmp, merged)
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
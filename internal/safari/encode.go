@@ -0,0 +1,146 @@
+package safari
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/timex"
+)
+
+// Encode writes the cookies in the binarycookies format.
+//
+// The cookies of a domain are stored on the same page.
+// Session cookies are written with an expiry of zero which Safari doesn't do.
+func Encode(w io.Writer, policy AcceptPolicy, kookies ...*kooky.Cookie) error {
+	var domains []string
+	byDomain := make(map[string][]*kooky.Cookie)
+	for _, c := range kookies {
+		if c == nil {
+			continue
+		}
+		if _, ok := byDomain[c.Domain]; !ok {
+			domains = append(domains, c.Domain)
+		}
+		byDomain[c.Domain] = append(byDomain[c.Domain], c)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(fileMagic[:])
+	_ = binary.Write(&buf, binary.BigEndian, int32(len(domains)))
+	pages := make([][]byte, 0, len(domains))
+	for _, domain := range domains {
+		page := encodePage(byDomain[domain])
+		_ = binary.Write(&buf, binary.BigEndian, int32(len(page)))
+		pages = append(pages, page)
+	}
+	var sum uint32
+	for _, page := range pages {
+		buf.Write(page)
+		sum += pageChecksum(page)
+	}
+	_ = binary.Write(&buf, binary.BigEndian, sum)
+
+	plist := encodePlistDict(acceptPolicyKey, uint8(policy))
+	buf.Write(footerSignature[:])
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(plist)))
+	buf.Write(plist)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func encodePage(kookies []*kooky.Cookie) []byte {
+	encoded := make([][]byte, len(kookies))
+	for i, c := range kookies {
+		encoded[i] = encodeCookie(c)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(pageMagic[:])
+	_ = binary.Write(&buf, binary.LittleEndian, int32(len(kookies)))
+	// header, offsets and the 4 zero bytes ending the header
+	offset := buf.Len() + 4*len(kookies) + 4
+	for _, c := range encoded {
+		_ = binary.Write(&buf, binary.LittleEndian, int32(offset))
+		offset += len(c)
+	}
+	buf.Write([]byte{0, 0, 0, 0})
+	for _, c := range encoded {
+		buf.Write(c)
+	}
+	return buf.Bytes()
+}
+
+func encodeCookie(c *kooky.Cookie) []byte {
+	ch := cookieHeader{}
+	if c.Secure {
+		ch.Flags |= flagSecure
+	}
+	if c.HttpOnly {
+		ch.Flags |= flagHttpOnly
+	}
+	switch c.SameSite {
+	case http.SameSiteLaxMode:
+		ch.Flags |= flagSameSiteLax
+	case http.SameSiteStrictMode:
+		ch.Flags |= flagSameSiteStrict
+	}
+	if !c.Expires.IsZero() {
+		ch.ExpirationDate = timex.ToSafariTime(c.Expires)
+	}
+	if !c.Creation.IsZero() {
+		ch.CreationDate = timex.ToSafariTime(c.Creation)
+	}
+	port, hasPort := cookiePort(c)
+
+	offset := int32(binary.Size(ch))
+	if hasPort {
+		ch.HasPort = 1
+		offset += 2
+	}
+	var strs bytes.Buffer
+	for _, f := range []struct {
+		offset *int32
+		value  string
+	}{
+		{&ch.UrlOffset, c.Domain},
+		{&ch.NameOffset, c.Name},
+		{&ch.PathOffset, c.Path},
+		{&ch.ValueOffset, c.Value},
+	} {
+		*f.offset = offset + int32(strs.Len())
+		strs.WriteString(f.value)
+		strs.WriteByte(0)
+	}
+	ch.Size = offset + int32(strs.Len())
+
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, ch)
+	if hasPort {
+		_ = binary.Write(&buf, binary.LittleEndian, port)
+	}
+	buf.Write(strs.Bytes())
+	return buf.Bytes()
+}
+
+// cookiePort returns the first port of the "port" attribute in Cookie.Unparsed.
+func cookiePort(c *kooky.Cookie) (uint16, bool) {
+	for _, attr := range c.Unparsed {
+		k, v, ok := strings.Cut(attr, `=`)
+		if !ok || !strings.EqualFold(k, `port`) {
+			continue
+		}
+		v, _, _ = strings.Cut(strings.Trim(v, `"`), `,`)
+		port, err := strconv.ParseUint(strings.TrimSpace(v), 10, 16)
+		if err != nil {
+			return 0, false
+		}
+		return uint16(port), true
+	}
+	return 0, false
+}
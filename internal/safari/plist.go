@@ -0,0 +1,159 @@
+package safari
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Minimal binary property list support for the footer of binarycookies files:
+// a dictionary with ASCII string keys and integer values.
+// https://opensource.apple.com/source/CF/CF-1153.18/CFBinaryPList.c
+
+const bplistMagic = `bplist00`
+
+type bplistTrailer struct {
+	Unused            [6]byte
+	OffsetIntSize     uint8
+	ObjectRefSize     uint8
+	NumObjects        uint64
+	TopObject         uint64
+	OffsetTableOffset uint64
+}
+
+func decodePlistDict(b []byte) (map[string]int64, error) {
+	const trailerSize = 32
+	if len(b) < len(bplistMagic)+trailerSize || string(b[:len(bplistMagic)]) != bplistMagic {
+		return nil, errors.New(`not a binary plist`)
+	}
+	var tr bplistTrailer
+	if err := binary.Read(bytes.NewReader(b[len(b)-trailerSize:]), binary.BigEndian, &tr); err != nil {
+		return nil, err
+	}
+	// bounds are checked by division, the values are untrusted and the products could overflow
+	tableEnd := uint64(len(b) - trailerSize)
+	if tr.OffsetIntSize == 0 || tr.OffsetIntSize > 8 || tr.ObjectRefSize == 0 || tr.ObjectRefSize > 8 ||
+		tr.OffsetTableOffset > tableEnd || tr.NumObjects > (tableEnd-tr.OffsetTableOffset)/uint64(tr.OffsetIntSize) {
+		return nil, errors.New(`malformed binary plist trailer`)
+	}
+	offsets := make([]uint64, tr.NumObjects)
+	for i := range offsets {
+		start := tr.OffsetTableOffset + uint64(i)*uint64(tr.OffsetIntSize)
+		offsets[i] = beUint(b[start : start+uint64(tr.OffsetIntSize)])
+	}
+	object := func(ref uint64) ([]byte, error) {
+		if ref >= uint64(len(offsets)) || offsets[ref] >= uint64(len(b)) {
+			return nil, fmt.Errorf("invalid object reference %d", ref)
+		}
+		return b[offsets[ref]:], nil
+	}
+
+	top, err := object(tr.TopObject)
+	if err != nil {
+		return nil, err
+	}
+	if top[0]>>4 != 0xD {
+		return nil, fmt.Errorf("top object is not a dictionary (marker %#x)", top[0])
+	}
+	count, refs, err := plistLength(top)
+	if err != nil {
+		return nil, err
+	}
+	refSize := uint64(tr.ObjectRefSize)
+	if count > uint64(len(refs))/(2*refSize) {
+		return nil, errors.New(`truncated dictionary`)
+	}
+	dict := make(map[string]int64, count)
+	for i := range count {
+		keyObj, err := object(beUint(refs[i*refSize : (i+1)*refSize]))
+		if err != nil {
+			return nil, err
+		}
+		valObj, err := object(beUint(refs[(count+i)*refSize : (count+i+1)*refSize]))
+		if err != nil {
+			return nil, err
+		}
+		if keyObj[0]>>4 != 0x5 {
+			return nil, fmt.Errorf("dictionary key is not an ASCII string (marker %#x)", keyObj[0])
+		}
+		n, key, err := plistLength(keyObj)
+		if err != nil || uint64(len(key)) < n {
+			return nil, errors.New(`truncated dictionary key`)
+		}
+		if valObj[0]>>4 != 0x1 {
+			// only integers are of interest
+			continue
+		}
+		v, err := plistInt(valObj)
+		if err != nil {
+			return nil, err
+		}
+		dict[string(key[:n])] = v
+	}
+	return dict, nil
+}
+
+// plistLength returns the length encoded in the low nibble of the marker byte
+// or in the following integer object and the remaining bytes.
+func plistLength(obj []byte) (uint64, []byte, error) {
+	if n := obj[0] & 0xF; n != 0xF {
+		return uint64(n), obj[1:], nil
+	}
+	if len(obj) < 2 || obj[1]>>4 != 0x1 {
+		return 0, nil, errors.New(`malformed object length`)
+	}
+	size := 1 << (obj[1] & 0xF)
+	if len(obj) < 2+size {
+		return 0, nil, errors.New(`truncated object length`)
+	}
+	return beUint(obj[2 : 2+size]), obj[2+size:], nil
+}
+
+func plistInt(obj []byte) (int64, error) {
+	size := 1 << (obj[0] & 0xF)
+	if len(obj) < 1+size || size > 8 {
+		return 0, errors.New(`malformed integer`)
+	}
+	// 1, 2 and 4 byte integers are unsigned, 8 byte integers signed
+	return int64(beUint(obj[1 : 1+size])), nil
+}
+
+func beUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+// encodePlistDict encodes a dictionary with a single integer value like Safari does.
+// The key has to be shorter than 256 bytes.
+func encodePlistDict(key string, value uint8) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(bplistMagic)
+	var offsets []int
+	// dictionary with 1 entry: key is object 1, value object 2
+	offsets = append(offsets, buf.Len())
+	buf.Write([]byte{0xD1, 0x01, 0x02})
+	offsets = append(offsets, buf.Len())
+	if len(key) < 0xF {
+		buf.WriteByte(0x50 | byte(len(key)))
+	} else {
+		buf.Write([]byte{0x5F, 0x10, byte(len(key))})
+	}
+	buf.WriteString(key)
+	offsets = append(offsets, buf.Len())
+	buf.Write([]byte{0x10, value})
+	offsetTableOffset := buf.Len()
+	for _, o := range offsets {
+		buf.WriteByte(byte(o))
+	}
+	_ = binary.Write(&buf, binary.BigEndian, bplistTrailer{
+		OffsetIntSize:     1,
+		ObjectRefSize:     1,
+		NumObjects:        uint64(len(offsets)),
+		OffsetTableOffset: uint64(offsetTableOffset),
+	})
+	return buf.Bytes()
+}
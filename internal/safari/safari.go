@@ -0,0 +1,310 @@
+// Package safari reads the Cookies.binarycookies files of Safari and other WebKit apps on macOS and iOS.
+// Thanks to https://github.com/as0ler/BinaryCookieReader
+package safari
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/iterx"
+	"github.com/browserutils/kooky/internal/timex"
+)
+
+// File layout (page sizes, checksum and footer big endian, pages little endian):
+//
+//	"cook" | number of pages | page sizes | pages | checksum | footer | binary plist
+//
+// A page is made of its header, the cookie offsets, 4 zero bytes and the cookies.
+// The checksum is the sum of every 4th byte of each page.
+// The footer is a signature followed by the size of the binary plist
+// holding the NSHTTPCookieAcceptPolicy.
+
+var (
+	fileMagic       = [4]byte{'c', 'o', 'o', 'k'}
+	pageMagic       = [4]byte{0x00, 0x00, 0x01, 0x00}
+	footerSignature = [4]byte{0x07, 0x17, 0x20, 0x05}
+)
+
+type fileHeader struct {
+	Magic    [4]byte
+	NumPages int32
+}
+
+type pageHeader struct {
+	Header     [4]byte
+	NumCookies int32
+}
+
+type cookieHeader struct {
+	Size             int32
+	Version          int32
+	Flags            int32
+	HasPort          int32
+	UrlOffset        int32
+	NameOffset       int32
+	PathOffset       int32
+	ValueOffset      int32
+	CommentOffset    int32
+	CommentURLOffset int32
+	ExpirationDate   float64
+	CreationDate     float64
+}
+
+// cookie flags
+const (
+	flagSecure         = 1 << 0
+	flagHttpOnly       = 1 << 2
+	flagSameSiteLax    = 1 << 3
+	flagSameSiteStrict = 1 << 4
+)
+
+// AcceptPolicy is the NSHTTPCookieAcceptPolicy stored at the end of the file.
+type AcceptPolicy int
+
+const (
+	AcceptPolicyAlways                     AcceptPolicy = 0
+	AcceptPolicyNever                      AcceptPolicy = 1
+	AcceptPolicyOnlyFromMainDocumentDomain AcceptPolicy = 2 // default
+)
+
+const acceptPolicyKey = `NSHTTPCookieAcceptPolicy`
+
+// ErrChecksum is returned after the cookies of a file with a wrong checksum.
+var ErrChecksum = errors.New(`binarycookies checksum mismatch`)
+
+// CookieStore reads a Cookies.binarycookies file.
+type CookieStore struct {
+	cookies.DefaultCookieStore
+	// AcceptPolicy is set after reading the cookies.
+	AcceptPolicy AcceptPolicy
+}
+
+var _ cookies.CookieStore = (*CookieStore)(nil)
+
+func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	return func(yield func(*kooky.Cookie, error) bool) {
+		if s == nil {
+			yield(nil, errors.New(`cookie store is nil`))
+			return
+		}
+		if err := s.Open(); err != nil {
+			yield(nil, err)
+			return
+		}
+		if s.File == nil {
+			yield(nil, errors.New(`file is nil`))
+			return
+		}
+
+		var header fileHeader
+		err := binary.Read(s.File, binary.BigEndian, &header)
+		if err != nil {
+			yield(nil, fmt.Errorf("error reading header: %v", err))
+			return
+		}
+		if header.Magic != fileMagic {
+			yield(nil, fmt.Errorf("expected first 4 bytes to be %q; got %q", fileMagic[:], header.Magic[:]))
+			return
+		}
+
+		pageSizes := make([]int32, header.NumPages)
+		if err = binary.Read(s.File, binary.BigEndian, &pageSizes); err != nil {
+			yield(nil, fmt.Errorf("error reading page sizes: %w", err))
+			return
+		}
+
+		// read cookies
+		var sum uint32
+		for i, pageSize := range pageSizes {
+			page := make([]byte, pageSize)
+			if _, err := io.ReadFull(s.File, page); err != nil {
+				yield(nil, fmt.Errorf("error reading page %d: %w", i, err))
+				return
+			}
+			sum += pageChecksum(page)
+			if !s.readPage(page, i, yield, filters...) {
+				return
+			}
+		}
+
+		var checksum uint32
+		if err = binary.Read(s.File, binary.BigEndian, &checksum); err != nil {
+			yield(nil, fmt.Errorf("error reading checksum: %w", err))
+			return
+		}
+		if checksum != sum {
+			yield(nil, fmt.Errorf("%w: stored %#x, computed %#x", ErrChecksum, checksum, sum))
+			return
+		}
+
+		policy, err := readFooter(s.File)
+		if err != nil {
+			yield(nil, fmt.Errorf("error reading footer: %w", err))
+			return
+		}
+		s.AcceptPolicy = policy
+	}
+}
+
+func pageChecksum(page []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(page); i += 4 {
+		sum += uint32(page[i])
+	}
+	return sum
+}
+
+// readFooter returns the accept policy from the binary plist after the footer signature.
+func readFooter(r io.Reader) (AcceptPolicy, error) {
+	var footer struct {
+		Signature [4]byte
+		PlistSize uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &footer); err != nil {
+		if errors.Is(err, io.EOF) {
+			// no footer
+			return AcceptPolicyOnlyFromMainDocumentDomain, nil
+		}
+		return 0, err
+	}
+	if footer.Signature != footerSignature {
+		return 0, fmt.Errorf("expected signature %x; got %x", footerSignature, footer.Signature)
+	}
+	b := make([]byte, footer.PlistSize)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return 0, err
+	}
+	dict, err := decodePlistDict(b)
+	if err != nil {
+		return 0, err
+	}
+	policy, ok := dict[acceptPolicyKey]
+	if !ok {
+		return AcceptPolicyOnlyFromMainDocumentDomain, nil
+	}
+	return AcceptPolicy(policy), nil
+}
+
+func (s *CookieStore) readPage(bb []byte, page int, yield func(*kooky.Cookie, error) bool, filters ...kooky.Filter) bool {
+	yld := func(c *kooky.Cookie, e error) bool {
+		if e != nil {
+			e = fmt.Errorf("error reading page %d: %w", page, e)
+		}
+		return iterx.CookieFilterYield(context.Background(), c, e, yield, filters...)
+	}
+
+	r := bytes.NewReader(bb)
+
+	var header pageHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return yld(nil, fmt.Errorf("error reading header: %w", err))
+	}
+	if header.Header != pageMagic {
+		return yld(nil, fmt.Errorf("expected first 4 bytes of page to be %v; got %v", pageMagic, header.Header))
+	}
+
+	cookieOffsets := make([]int32, header.NumCookies)
+	if err := binary.Read(r, binary.LittleEndian, &cookieOffsets); err != nil {
+		return yld(nil, fmt.Errorf("error reading cookie offsets: %w", err))
+	}
+
+	for i, cookieOffset := range cookieOffsets {
+		r.Seek(int64(cookieOffset), io.SeekStart)
+		cookie, err := s.readCookie(r)
+		if err != nil {
+			return yld(nil, fmt.Errorf("cookie %d: %w", i, err))
+		}
+		if !yld(cookie, nil) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *CookieStore) readCookie(r io.ReadSeeker) (*kooky.Cookie, error) {
+	start, _ := r.Seek(0, io.SeekCurrent)
+	var ch cookieHeader
+	if err := binary.Read(r, binary.LittleEndian, &ch); err != nil {
+		return nil, err
+	}
+
+	// zero is written for session cookies and unknown creation dates
+	var expiry, creation time.Time
+	if ch.ExpirationDate != 0 {
+		expiry = timex.FromSafariTime(ch.ExpirationDate)
+	}
+	if ch.CreationDate != 0 {
+		creation = timex.FromSafariTime(ch.CreationDate)
+	}
+
+	var port uint16
+	if ch.HasPort != 0 {
+		if err := binary.Read(r, binary.LittleEndian, &port); err != nil {
+			return nil, fmt.Errorf("reading port: %w", err)
+		}
+	}
+
+	url, err := s.readString(r, "url", start, ch.UrlOffset)
+	if err != nil {
+		return nil, err
+	}
+	name, err := s.readString(r, "name", start, ch.NameOffset)
+	if err != nil {
+		return nil, err
+	}
+	path, err := s.readString(r, "path", start, ch.PathOffset)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.readString(r, "value", start, ch.ValueOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	cookie := &kooky.Cookie{}
+	cookie.Expires = expiry
+	cookie.Creation = creation
+	cookie.Name = name
+	cookie.Value = value
+	cookie.Domain = url
+	cookie.Path = path
+	cookie.Secure = ch.Flags&flagSecure != 0
+	cookie.HttpOnly = ch.Flags&flagHttpOnly != 0
+	switch {
+	case ch.Flags&flagSameSiteStrict != 0:
+		cookie.SameSite = http.SameSiteStrictMode
+	case ch.Flags&flagSameSiteLax != 0:
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+	if ch.HasPort != 0 {
+		// same attribute as in LWP files
+		cookie.Unparsed = append(cookie.Unparsed, `port=`+strconv.Itoa(int(port)))
+	}
+	cookie.Browser = s
+
+	return cookie, nil
+}
+
+func (s *CookieStore) readString(r io.ReadSeeker, field string, start int64, offset int32) (string, error) {
+	if _, err := r.Seek(start+int64(offset), io.SeekStart); err != nil {
+		return "", fmt.Errorf("seeking for %q at offset %d", field, offset)
+	}
+	b := bufio.NewReader(r)
+	value, err := b.ReadString(0)
+	if err != nil {
+		return "", fmt.Errorf("reading for %q at offset %d", field, offset)
+	}
+
+	return value[:len(value)-1], nil
+}
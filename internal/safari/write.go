@@ -0,0 +1,62 @@
+package safari
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+var _ kooky.CookieWriter = (*CookieStore)(nil)
+
+// WriteCookies merges the cookies into the binarycookies file of the cookie store.
+func (s *CookieStore) WriteCookies(kookies ...*kooky.Cookie) error {
+	if s == nil {
+		return errors.New(`cookie store is nil`)
+	}
+	// the file is replaced
+	if err := s.Close(); err != nil {
+		return err
+	}
+	return WriteFile(s.FileNameStr, kookies...)
+}
+
+// WriteFile merges the cookies into the binarycookies file filename.
+// The file is created if it does not exist.
+func WriteFile(filename string, updates ...*kooky.Cookie) error {
+	var existing []*kooky.Cookie
+	policy := AcceptPolicyOnlyFromMainDocumentDomain
+	if _, err := os.Stat(filename); err == nil {
+		s := &CookieStore{}
+		s.FileNameStr = filename
+		existing, err = s.TraverseCookies().ReadAllCookies(context.Background())
+		s.Close()
+		if err != nil {
+			return err
+		}
+		policy = s.AcceptPolicy
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	merged := cookies.MergeCookies(existing, updates, time.Now())
+
+	// write to a temporary file first so that readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(filename), `.`+filepath.Base(filename)+`.*`)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := Encode(tmp, policy, merged...); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
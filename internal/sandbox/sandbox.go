@@ -0,0 +1,38 @@
+// Package sandbox locates the data of browsers installed as Flatpak or Snap on Linux.
+package sandbox
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// FlatpakDir() returns the per-app directory "$HOME/.var/app/<appID>/<pathParts>".
+func FlatpakDir(home, appID string, pathParts ...string) string {
+	return filepath.Join(append([]string{home, `.var`, `app`, appID}, pathParts...)...)
+}
+
+// FlatpakConfigDir() returns the directory below the XDG_CONFIG_HOME of a Flatpak app,
+// "$HOME/.var/app/<appID>/config/<pathParts>".
+func FlatpakConfigDir(home, appID string, pathParts ...string) string {
+	return FlatpakDir(home, appID, append([]string{`config`}, pathParts...)...)
+}
+
+// SnapDir() returns "$HOME/snap/<pathParts>".
+//
+// The first path element is the snap name, followed by "common" or "current"
+// ($SNAP_USER_COMMON and $SNAP_USER_DATA).
+func SnapDir(home string, pathParts ...string) string {
+	return filepath.Join(append([]string{home, `snap`}, pathParts...)...)
+}
+
+// FlatpakAppID() returns the app ID of a path within a Flatpak app directory
+// "$HOME/.var/app/<appID>/..." or an empty string.
+func FlatpakAppID(path string) string {
+	const marker = `/.var/app/`
+	_, rest, ok := strings.Cut(filepath.ToSlash(path), marker)
+	if !ok {
+		return ``
+	}
+	appID, _, _ := strings.Cut(rest, `/`)
+	return appID
+}
@@ -0,0 +1,148 @@
+// Package sqlitex writes to the SQLite cookie databases of browsers.
+//
+// Reading is done with github.com/go-sqlite/sqlite3 which is read-only.
+// The "sqlite" driver for writing is registered by importing github.com/browserutils/kooky/sqlitewrite.
+package sqlitex
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+const driverName = `sqlite`
+
+// ErrNoDriver is returned by Open if github.com/browserutils/kooky/sqlitewrite isn't imported.
+var ErrNoDriver = errors.New(`writing SQLite databases is not enabled, import github.com/browserutils/kooky/sqlitewrite`)
+
+// Enabled reports whether the SQLite driver for writing is registered.
+func Enabled() bool {
+	return slices.Contains(sql.Drivers(), driverName)
+}
+
+// Open opens the existing database filename for writing.
+//
+// Browsers lock their cookie databases while running,
+// writes fail with "database is locked" then.
+func Open(filename string) (*sql.DB, error) {
+	if !Enabled() {
+		return nil, ErrNoDriver
+	}
+	// don't create a new database
+	if _, err := os.Stat(filename); err != nil {
+		return nil, err
+	}
+	u := url.URL{Scheme: `file`, OmitHost: true, Path: filename, RawQuery: `_pragma=busy_timeout(5000)`}
+	db, err := sql.Open(driverName, u.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Column describes a table column.
+type Column struct {
+	Name     string
+	Type     string
+	NotNull  bool
+	HasDflt  bool
+	Primary  bool
+	lowerKey string
+}
+
+// Table holds the columns of a table.
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// ReadTable returns the columns of the table name.
+func ReadTable(tx *sql.Tx, name string) (*Table, error) {
+	rows, err := tx.Query(`SELECT name, type, "notnull", dflt_value, pk FROM pragma_table_info(?)`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	t := &Table{Name: name}
+	for rows.Next() {
+		var c Column
+		var dflt sql.NullString
+		var pk int
+		if err := rows.Scan(&c.Name, &c.Type, &c.NotNull, &dflt, &pk); err != nil {
+			return nil, err
+		}
+		c.HasDflt = dflt.Valid
+		c.Primary = pk > 0
+		c.lowerKey = strings.ToLower(c.Name)
+		t.Columns = append(t.Columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(t.Columns) == 0 {
+		return nil, fmt.Errorf("table %q not found", name)
+	}
+	return t, nil
+}
+
+// Has reports whether the table has the column.
+func (t *Table) Has(column string) bool {
+	column = strings.ToLower(column)
+	for _, c := range t.Columns {
+		if c.lowerKey == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Insert inserts a row with the values of the existing columns.
+// Values of missing columns are ignored, so that the same values
+// can be used with databases of older and newer browser versions.
+// Unknown NOT NULL columns without default get the zero value of their type.
+func (t *Table) Insert(tx *sql.Tx, values map[string]any) error {
+	lowerValues := make(map[string]any, len(values))
+	for k, v := range values {
+		lowerValues[strings.ToLower(k)] = v
+	}
+	var names, params []string
+	var args []any
+	for _, c := range t.Columns {
+		v, ok := lowerValues[c.lowerKey]
+		if !ok {
+			if !c.NotNull || c.HasDflt || c.Primary {
+				continue
+			}
+			v = zeroValue(c.Type)
+		}
+		names = append(names, `"`+c.Name+`"`)
+		params = append(params, `?`)
+		args = append(args, v)
+	}
+	query := `INSERT INTO "` + t.Name + `" (` + strings.Join(names, `, `) + `) VALUES (` + strings.Join(params, `, `) + `)`
+	_, err := tx.Exec(query, args...)
+	return err
+}
+
+// zeroValue returns the zero value of the column type (SQLite type affinity).
+func zeroValue(typ string) any {
+	typ = strings.ToUpper(typ)
+	switch {
+	case strings.Contains(typ, `INT`):
+		return 0
+	case strings.Contains(typ, `CHAR`), strings.Contains(typ, `CLOB`), strings.Contains(typ, `TEXT`):
+		return ``
+	case len(typ) == 0, strings.Contains(typ, `BLOB`):
+		return []byte{}
+	default:
+		return 0
+	}
+}
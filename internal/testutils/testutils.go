@@ -1,6 +1,10 @@
 package testutils
 
-import "path/filepath"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 // GetTestDataFilePath returns the full path of a file in the testdata/ dir
 func GetTestDataFilePath(testFile string) (string, error) {
@@ -11,3 +15,22 @@ func GetTestDataFilePath(testFile string) (string, error) {
 
 	return filepath.Join(testdataPath, testFile), nil
 }
+
+// CopyTestDataFile copies a file of the testdata/ dir to dst, creating the parent directories.
+func CopyTestDataFile(tb testing.TB, testFile, dst string) {
+	tb.Helper()
+	src, err := GetTestDataFilePath(testFile)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	b, err := os.ReadFile(src)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.WriteFile(dst, b, 0o644); err != nil {
+		tb.Fatal(err)
+	}
+}
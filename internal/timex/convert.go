@@ -13,6 +13,11 @@ func FromFILETIME(timestamp_utc int64) time.Time {
 	return time.Unix(0, timestamp_utc*100)
 }
 
+// ToFILETIME is the inverse of FromFILETIME.
+func ToFILETIME(t time.Time) int64 {
+	return t.UnixNano()/100 + 116444736e9
+}
+
 func FromFILETIMESplit[T ~[4]byte | ~uint32 | ~int32 | ~uint64 | ~int64](low, high T) time.Time {
 	var lowInt64, highInt64 int64
 	switch lowTyp := any(low).(type) {
@@ -61,3 +66,8 @@ func FromSafariTime(floatSecs float64) time.Time {
 	seconds, frac := math.Modf(floatSecs)
 	return time.Unix(int64(seconds)+978307200, int64(frac*1000000000))
 }
+
+// ToSafariTime is the inverse of FromSafariTime.
+func ToSafariTime(t time.Time) float64 {
+	return float64(t.Unix()-978307200) + float64(t.Nanosecond())/1e9
+}
@@ -11,21 +11,30 @@ type signature struct {
 }
 
 var signatures = map[string][]signature{
-	`ese`:                {{start: 4, sig: []byte{0xEF, 0xCD, 0xAB, 0x89}}}, // ESE database
-	`sqlite`:             {{start: 0, sig: []byte("SQLite format 3\x00")}},  // SQLite 3 database
-	`konqueror`:          {{start: 0, sig: []byte("# KDE Cookie File")}},    // Konqueror cookie text file
-	`safari`:             {{start: 0, sig: []byte("cook")}},                 // Safari cookie binary file
-	`opera_cookies4_1.0`: {{start: 0, sig: []byte{0x00, 0x00, 0x10, 0x00}}}, // Opera Presto cookie binary file (file format v1.0) (cookies4.dat)
+	`ese`:       {{start: 4, sig: []byte{0xEF, 0xCD, 0xAB, 0x89}}}, // ESE database
+	`sqlite`:    {{start: 0, sig: []byte("SQLite format 3\x00")}},  // SQLite 3 database
+	`konqueror`: {{start: 0, sig: []byte("# KDE Cookie File")}},    // Konqueror cookie text file
+	`safari`:    {{start: 0, sig: []byte("cook")}},                 // Safari cookie binary file
 	`ie_cache`: {
 		{start: 0, sig: []byte("Client UrlCache MMF")}, // Internet Explorer cache file
 		{start: 0, sig: []byte("WINE URLCache Ver ")},  // wine index.dat // TODO
 	},
+	`lwp`: {{start: 0, sig: []byte("#LWP-Cookies-2.0")}}, // Python http.cookiejar.LWPCookieJar file
 	`netscape`: {
 		{start: 0, sig: []byte("# Netscape HTTP Cookie File")}, // Netscape cookie text file
 		{start: 0, sig: []byte("# HTTP Cookie File")},          // Netscape cookie text file (not strict)
 	},
 }
 
+func init() {
+	// Opera Presto cookie binary file (cookies4.dat), file format version 1.x
+	var operaSigs []signature
+	for b := byte(0x10); b <= 0x1f; b++ {
+		operaSigs = append(operaSigs, signature{start: 0, sig: []byte{0x00, 0x00, b}})
+	}
+	signatures[`opera_cookies4`] = operaSigs
+}
+
 // DetectFileType opens filename and identifies its type by magic bytes.
 // On success the returned file is open and seeked to the start;
 // the caller is responsible for closing it.
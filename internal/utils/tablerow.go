@@ -43,6 +43,10 @@ func (row TableRow) Int64(columnName string) (int64, error) {
 		return int64(value), nil
 	case int32:
 		return int64(value), nil
+	case int16:
+		return int64(value), nil
+	case int8:
+		return int64(value), nil
 	case int:
 		return int64(value), nil
 	default:
@@ -1,23 +1,25 @@
-package epiphany
+package webkitgtk
 
 import (
 	"errors"
 	"os"
+	"path/filepath"
 
 	"github.com/browserutils/kooky/internal/cookies"
 	"github.com/browserutils/kooky/internal/utils"
 	"github.com/go-sqlite/sqlite3"
 )
 
-type epiphanyCookieStore struct {
+// CookieStore reads the SQLite database of libsoup's SoupCookieJarDB used by WebKitGTK and WPE WebKit browsers.
+type CookieStore struct {
 	cookies.DefaultCookieStore
 	Database *sqlite3.DbFile
 	dbFile   *os.File
 }
 
-var _ cookies.CookieStore = (*epiphanyCookieStore)(nil)
+var _ cookies.CookieStore = (*CookieStore)(nil)
 
-func (s *epiphanyCookieStore) Open() error {
+func (s *CookieStore) Open() error {
 	if s == nil {
 		return errors.New(`cookie store is nil`)
 	}
@@ -40,7 +42,7 @@ func (s *epiphanyCookieStore) Open() error {
 	return nil
 }
 
-func (s *epiphanyCookieStore) Close() error {
+func (s *CookieStore) Close() error {
 	if s == nil {
 		return errors.New(`cookie store is nil`)
 	}
@@ -59,3 +61,15 @@ func (s *epiphanyCookieStore) Close() error {
 
 	return err
 }
+
+// DataHome() returns "${XDG_DATA_HOME:-$HOME/.local/share}" where WebKitGTK browsers store their cookies.
+func DataHome() (string, error) {
+	if dir, ok := os.LookupEnv(`XDG_DATA_HOME`); ok && len(dir) > 0 {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ``, err
+	}
+	return filepath.Join(home, `.local`, `share`), nil
+}
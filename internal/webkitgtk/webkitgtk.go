@@ -0,0 +1,112 @@
+package webkitgtk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/iterx"
+	"github.com/browserutils/kooky/internal/utils"
+)
+
+func (s *CookieStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	if s == nil {
+		return iterx.ErrCookieSeq(errors.New(`cookie store is nil`))
+	}
+	if err := s.Open(); err != nil {
+		return iterx.ErrCookieSeq(err)
+	} else if s.Database == nil {
+		return iterx.ErrCookieSeq(errors.New(`database is nil`))
+	}
+
+	// Epiphany originally used a Mozilla Gecko backend but later switched to WebKit.
+	// For possible deviations from the firefox database layout
+	// it might be better not to depend on the firefox implementation.
+
+	visitor := func(yield func(*kooky.Cookie, error) bool) func(rowId *int64, row utils.TableRow) error {
+		return func(rowId *int64, row utils.TableRow) error {
+			cookie := kooky.Cookie{}
+			var err error
+
+			// Name
+			cookie.Name, err = row.String(`name`)
+			if err != nil {
+				return err
+			}
+
+			// Value
+			cookie.Value, err = row.String(`value`)
+			if err != nil {
+				return err
+			}
+
+			// Host
+			cookie.Domain, err = row.String(`host`)
+			if err != nil {
+				return err
+			}
+
+			// Path
+			cookie.Path, err = row.String(`path`)
+			if err != nil {
+				return err
+			}
+
+			// Expires
+			expiry, err := utils.ValueOrFallback[int64](row, `expiry`, 0, true)
+			if err != nil {
+				return err
+			}
+			cookie.Expires = time.Unix(expiry, 0)
+
+			// Secure
+			cookie.Secure, err = row.Bool(`isSecure`)
+			if err != nil {
+				return err
+			}
+
+			// HttpOnly
+			cookie.HttpOnly, err = row.Bool(`isHttpOnly`)
+			if err != nil {
+				return err
+			}
+
+			// SameSite (column added in libsoup 2.70)
+			if sameSite, err := row.Int64(`sameSite`); err == nil {
+				cookie.SameSite = sameSiteFromSoup(sameSite)
+			}
+
+			cookie.Browser = s
+
+			if !iterx.CookieFilterYield(context.Background(), &cookie, nil, yield, filters...) {
+				return iterx.ErrYieldEnd
+			}
+
+			return nil
+		}
+	}
+	seq := func(yield func(*kooky.Cookie, error) bool) {
+		err := utils.VisitTableRows(s.Database, `moz_cookies`, map[string]string{}, visitor(yield))
+		if err != nil && !errors.Is(err, iterx.ErrYieldEnd) {
+			yield(nil, err)
+		}
+	}
+
+	return seq
+}
+
+// sameSiteFromSoup converts the SoupSameSitePolicy stored in the database.
+func sameSiteFromSoup(policy int64) http.SameSite {
+	switch policy {
+	case 0:
+		return http.SameSiteNoneMode
+	case 1:
+		return http.SameSiteLaxMode
+	case 2:
+		return http.SameSiteStrictMode
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,134 @@
+// Package wsrpc is a minimal client for the JSON messages over WebSocket
+// of the browser remote protocols (Chrome DevTools Protocol, WebDriver BiDi).
+//
+// Commands are {"id", "method", "params"} objects, replies carry the same "id"
+// and either a "result" or an "error". Messages without "id" are events.
+package wsrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// Client sends one command at a time and waits for its reply.
+type Client struct {
+	conn       *websocket.Conn
+	mu         sync.Mutex // one call at a time
+	id         int64
+	replyError func(reply []byte) error
+}
+
+// Dial connects to the WebSocket URL.
+//
+// The Origin header is set to the HTTP origin of the URL,
+// browsers reject other origins unless started with "--remote-allow-origins".
+//
+// replyError is called with replies that have an "error" member
+// and returns the protocol specific error.
+func Dial(ctx context.Context, wsURL string, replyError func(reply []byte) error) (*Client, error) {
+	u, err := url.Parse(wsURL)
+	if err != nil {
+		return nil, err
+	}
+	origin := &url.URL{Scheme: `http`, Host: u.Host}
+	if u.Scheme == `wss` {
+		origin.Scheme = `https`
+	}
+	cfg, err := websocket.NewConfig(wsURL, origin.String())
+	if err != nil {
+		return nil, err
+	}
+	conn, err := cfg.DialContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, replyError: replyError}, nil
+}
+
+type command struct {
+	ID     int64  `json:"id"`
+	Method string `json:"method"`
+	Params any    `json:"params"`
+}
+
+type reply struct {
+	ID     *int64          `json:"id"`
+	Type   string          `json:"type"` // WebDriver BiDi: success, error or event
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// Call sends the command and decodes the result into result (if not nil).
+// Events received in the meantime are dropped.
+func (c *Client) Call(ctx context.Context, method string, params, result any) error {
+	if c == nil || c.conn == nil {
+		return errors.New(`not connected`)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(deadline)
+		defer c.conn.SetDeadline(time.Time{})
+	}
+	// unblock reads and writes on cancellation
+	stop := context.AfterFunc(ctx, func() { c.conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	err := c.call(method, params, result)
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, method, err)
+	}
+	return nil
+}
+
+func (c *Client) call(method string, params, result any) error {
+	c.id++
+	id := c.id
+	if params == nil {
+		params = struct{}{}
+	}
+	if err := websocket.JSON.Send(c.conn, &command{ID: id, Method: method, Params: params}); err != nil {
+		return err
+	}
+	for {
+		var msg []byte
+		if err := websocket.Message.Receive(c.conn, &msg); err != nil {
+			return err
+		}
+		var r reply
+		if err := json.Unmarshal(msg, &r); err != nil {
+			return err
+		}
+		if r.ID == nil || *r.ID != id || r.Type == `event` {
+			continue
+		}
+		if len(r.Error) > 0 && string(r.Error) != `null` {
+			if c.replyError != nil {
+				return c.replyError(msg)
+			}
+			return fmt.Errorf(`error reply: %s`, r.Error)
+		}
+		if result == nil || len(r.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(r.Result, result)
+	}
+}
+
+func (c *Client) Close() error {
+	if c == nil || c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
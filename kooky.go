@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"iter"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -15,7 +18,9 @@ type Cookie struct {
 	http.Cookie
 	Creation  time.Time
 	Container string
-	Browser   BrowserInfo
+	// PartitionKey is the top-level site of a partitioned (CHIPS) cookie, e.g. "https://example.com".
+	PartitionKey string
+	Browser      BrowserInfo
 }
 
 // Cookie retrieving functions in this package like TraverseCookies(), ReadCookies(), AllCookies()
@@ -44,48 +49,52 @@ func AllCookies(filters ...Filter) Cookies {
 // https://github.com/golang/go/issues/54580
 // encoding/json/v2 "format"(?) might make this unnecessary
 
+type jsonCookie struct {
+	// net/http.Cookie
+	Name        string        `json:"name"`
+	Value       string        `json:"value"`
+	Quoted      bool          `json:"quoted"`
+	Path        string        `json:"path"`
+	Domain      string        `json:"domain"`
+	Expires     *jsonTime     `json:"expires,omitempty"`
+	RawExpires  string        `json:"raw_expires,omitempty"`
+	MaxAge      int           `json:"max_age"`
+	Secure      bool          `json:"secure"`
+	HttpOnly    bool          `json:"http_only"`
+	SameSite    http.SameSite `json:"same_site"`
+	Partitioned bool          `json:"partitioned"`
+	Raw         string        `json:"raw,omitempty"`
+	Unparsed    []string      `json:"unparsed,omitempty"`
+	// extra fields
+	Creation         *jsonTime `json:"creation,omitempty"`
+	Browser          string    `json:"browser,omitempty"`
+	Profile          string    `json:"profile,omitempty"`
+	IsDefaultProfile bool      `json:"is_default_profile"`
+	Container        string    `json:"container,omitempty"`
+	PartitionKey     string    `json:"partition_key,omitempty"`
+	FilePath         string    `json:"file_path,omitempty"`
+}
+
 func (c *Cookie) MarshalJSON() ([]byte, error) {
 	if c == nil {
 		return []byte(`null`), nil
 	}
-	c2 := &struct {
-		// net/http.Cookie
-		Name        string        `json:"name"`
-		Value       string        `json:"value"`
-		Quoted      bool          `json:"quoted"`
-		Path        string        `json:"path"`
-		Domain      string        `json:"domain"`
-		Expires     *jsonTime     `json:"expires,omitempty"`
-		RawExpires  string        `json:"raw_expires,omitempty"`
-		MaxAge      int           `json:"max_age"`
-		Secure      bool          `json:"secure"`
-		HttpOnly    bool          `json:"http_only"`
-		SameSite    http.SameSite `json:"same_site"`
-		Partitioned bool          `json:"partitioned"`
-		Raw         string        `json:"raw,omitempty"`
-		Unparsed    []string      `json:"unparsed,omitempty"`
-		// extra fields
-		Creation         *jsonTime `json:"creation,omitempty"`
-		Browser          string    `json:"browser,omitempty"`
-		Profile          string    `json:"profile,omitempty"`
-		IsDefaultProfile bool      `json:"is_default_profile"`
-		Container        string    `json:"container,omitempty"`
-		FilePath         string    `json:"file_path,omitempty"`
-	}{
-		Name:        c.Cookie.Name,
-		Value:       c.Cookie.Value,
-		Quoted:      c.Cookie.Quoted,
-		Path:        c.Cookie.Path,
-		Domain:      c.Cookie.Domain,
-		RawExpires:  c.Cookie.RawExpires,
-		MaxAge:      c.Cookie.MaxAge,
-		Secure:      c.Cookie.Secure,
-		HttpOnly:    c.Cookie.HttpOnly,
-		SameSite:    c.Cookie.SameSite,
-		Partitioned: c.Cookie.Partitioned,
-		Raw:         c.Cookie.Raw,
-		Unparsed:    c.Cookie.Unparsed,
-		Container:   c.Container,
+	c2 := &jsonCookie{
+		Name:         c.Cookie.Name,
+		Value:        c.Cookie.Value,
+		Quoted:       c.Cookie.Quoted,
+		Path:         c.Cookie.Path,
+		Domain:       c.Cookie.Domain,
+		RawExpires:   c.Cookie.RawExpires,
+		MaxAge:       c.Cookie.MaxAge,
+		Secure:       c.Cookie.Secure,
+		HttpOnly:     c.Cookie.HttpOnly,
+		SameSite:     c.Cookie.SameSite,
+		Partitioned:  c.Cookie.Partitioned,
+		Raw:          c.Cookie.Raw,
+		Unparsed:     c.Cookie.Unparsed,
+		Container:    c.Container,
+		PartitionKey: c.PartitionKey,
 	}
 	if !c.Cookie.Expires.IsZero() {
 		c2.Expires = &jsonTime{c.Cookie.Expires}
@@ -102,6 +111,66 @@ func (c *Cookie) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c2)
 }
 
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+// It reads the format written by MarshalJSON.
+// The browser related fields are restored as a static BrowserInfo.
+func (c *Cookie) UnmarshalJSON(b []byte) error {
+	if c == nil {
+		return errors.New(`nil receiver`)
+	}
+	var c2 jsonCookie
+	if err := json.Unmarshal(b, &c2); err != nil {
+		return err
+	}
+	*c = Cookie{
+		Cookie: http.Cookie{
+			Name:        c2.Name,
+			Value:       c2.Value,
+			Quoted:      c2.Quoted,
+			Path:        c2.Path,
+			Domain:      c2.Domain,
+			RawExpires:  c2.RawExpires,
+			MaxAge:      c2.MaxAge,
+			Secure:      c2.Secure,
+			HttpOnly:    c2.HttpOnly,
+			SameSite:    c2.SameSite,
+			Partitioned: c2.Partitioned,
+			Raw:         c2.Raw,
+			Unparsed:    c2.Unparsed,
+		},
+		Container:    c2.Container,
+		PartitionKey: c2.PartitionKey,
+	}
+	if c2.Expires != nil {
+		c.Expires = c2.Expires.Time
+	}
+	if c2.Creation != nil {
+		c.Creation = c2.Creation.Time
+	}
+	if len(c2.Browser) > 0 || len(c2.Profile) > 0 || len(c2.FilePath) > 0 || c2.IsDefaultProfile {
+		c.Browser = &staticBrowserInfo{
+			browser:          c2.Browser,
+			profile:          c2.Profile,
+			isDefaultProfile: c2.IsDefaultProfile,
+			filePath:         c2.FilePath,
+		}
+	}
+	return nil
+}
+
+// staticBrowserInfo holds BrowserInfo values detached from a cookie store.
+type staticBrowserInfo struct {
+	browser          string
+	profile          string
+	isDefaultProfile bool
+	filePath         string
+}
+
+func (b *staticBrowserInfo) Browser() string        { return b.browser }
+func (b *staticBrowserInfo) Profile() string        { return b.profile }
+func (b *staticBrowserInfo) IsDefaultProfile() bool { return b.isDefaultProfile }
+func (b *staticBrowserInfo) FilePath() string       { return b.filePath }
+
 type jsonTime struct{ time.Time }
 
 // MarshalJSON implements the [json.Marshaler] interface.
@@ -111,7 +180,43 @@ func (t jsonTime) MarshalJSON() ([]byte, error) {
 	if b, err := t.Time.MarshalJSON(); err == nil {
 		return b, nil
 	}
-	return []byte(t.Time.Format(`"` + time.RFC3339 + `"`)), nil
+	return []byte(t.Time.Format(`"` + time.RFC3339Nano + `"`)), nil
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+// Years with more than 4 digits and negative years are accepted.
+func (t *jsonTime) UnmarshalJSON(b []byte) error {
+	if err := t.Time.UnmarshalJSON(b); err == nil {
+		return nil
+	}
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	// split off the year
+	rest, negative := strings.CutPrefix(str, `-`)
+	i := strings.Index(rest, `-`)
+	if i < 4 {
+		return fmt.Errorf("invalid timestamp %q", str)
+	}
+	year, err := strconv.Atoi(rest[:i])
+	if err != nil {
+		return err
+	}
+	if negative {
+		year = -year
+	}
+	// parse the rest in a year with the same leap day, a Feb 29 has to be rejected in common years
+	refYear := `2001`
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		refYear = `2000`
+	}
+	tm, err := time.Parse(time.RFC3339Nano, refYear+rest[i:])
+	if err != nil {
+		return err
+	}
+	t.Time = time.Date(year, tm.Month(), tm.Day(), tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), tm.Location())
+	return nil
 }
 
 // for-rangeable cookie retriever
@@ -226,11 +331,17 @@ func MergeCookieSeqs(seqs ...CookieSeq) CookieSeq {
 	for _, s := range seqs {
 		sq = append(sq, iter.Seq2[*Cookie, error](s))
 	}
-	return CookieSeq(mergeSeqs(sq...))
+	return CookieSeq(mergeSeqs(context.Background(), 0, nil, sq...))
 }
 
-func mergeSeqs[S iter.Seq2[T, error], T any](seqs ...S) S {
+// mergeSeqs fans in the sequences, iterating at most limit of them at once (no limit if limit < 1).
+// The merged sequence ends early when ctx is done without waiting for blocked sequences.
+// drop (if not nil) is called with the values that are not yielded because the merged sequence ended early.
+func mergeSeqs[S iter.Seq2[T, error], T any](ctx context.Context, limit int, drop func(T), seqs ...S) S {
 	seqs0 := func(yield func(T, error) bool) {}
+	if drop == nil {
+		drop = func(T) {}
+	}
 	seqs2 := func(yield func(T, error) bool) {
 		type ve struct {
 			v T
@@ -238,43 +349,81 @@ func mergeSeqs[S iter.Seq2[T, error], T any](seqs ...S) S {
 		}
 		ch := make(chan ve, len(seqs))
 		quit := make(chan struct{})
+		var sem chan struct{}
+		if limit > 0 {
+			sem = make(chan struct{}, limit)
+		}
 
 		var wg sync.WaitGroup
 		// TODO: use wg.Go when switching to Go 1.25
-		for _, seq := range seqs {
-			if seq == nil {
-				continue
-			}
-			wg.Add(1)
-			go func(seq S) {
-				defer wg.Done()
-				for v, err := range seq {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, seq := range seqs {
+				if seq == nil {
+					continue
+				}
+				if sem != nil {
 					select {
-					case ch <- ve{v: v, e: err}:
+					case sem <- struct{}{}:
 					case <-quit:
 						return
 					}
 				}
-			}(seq)
-		}
+				wg.Add(1)
+				go func(seq S) {
+					defer wg.Done()
+					if sem != nil {
+						defer func() { <-sem }()
+					}
+					for v, err := range seq {
+						select {
+						case ch <- ve{v: v, e: err}:
+						case <-quit:
+							drop(v)
+							return
+						}
+					}
+				}(seq)
+			}
+		}()
 		go func() {
 			wg.Wait()
 			close(ch)
 		}()
 
-		for item := range ch {
-			if !yield(item.v, item.e) {
+		for {
+			if ctx.Err() != nil {
+				// don't wait for possibly hanging sequences
 				close(quit)
-				for range ch {
-				}
+				go func() {
+					for item := range ch {
+						drop(item.v)
+					}
+				}()
 				return
 			}
+			select {
+			case <-ctx.Done():
+				continue
+			case item, ok := <-ch:
+				if !ok {
+					return
+				}
+				if !yield(item.v, item.e) {
+					close(quit)
+					for item := range ch {
+						drop(item.v)
+					}
+					return
+				}
+			}
 		}
 	}
-	switch len(seqs) {
-	case 0:
+	switch {
+	case len(seqs) == 0:
 		return seqs0
-	case 1:
+	case len(seqs) == 1 && limit <= 0 && ctx.Done() == nil:
 		return seqs[0]
 	default:
 		return seqs2
@@ -0,0 +1,237 @@
+package kooky
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TraverseOption configures TraverseCookieStores().
+type TraverseOption func(*traverseConfig)
+
+type traverseConfig struct {
+	browsers     []string
+	maxParallel  int
+	storeTimeout time.Duration
+	timeout      time.Duration
+	root         string
+	sem          chan struct{}
+}
+
+// OnlyBrowsers restricts TraverseCookieStores() to the finders registered under the passed names.
+// Cookie stores of other browsers are neither searched for nor opened.
+func OnlyBrowsers(browsers ...string) TraverseOption {
+	return func(cfg *traverseConfig) {
+		cfg.browsers = append(cfg.browsers, browsers...)
+	}
+}
+
+// MaxParallelStores limits the number of finders searched and cookie stores read in parallel.
+func MaxParallelStores(n int) TraverseOption {
+	return func(cfg *traverseConfig) {
+		cfg.maxParallel = n
+	}
+}
+
+// StoreTimeout limits the time for reading a single cookie store.
+// On timeout the cookie sequence yields an error wrapping context.DeadlineExceeded
+// and continues with the other cookie stores.
+func StoreTimeout(d time.Duration) TraverseOption {
+	return func(cfg *traverseConfig) {
+		cfg.storeTimeout = d
+	}
+}
+
+// TraverseTimeout limits the time for searching and reading all cookie stores.
+// The timer starts with the iteration of the cookie store sequence.
+func TraverseTimeout(d time.Duration) TraverseOption {
+	return func(cfg *traverseConfig) {
+		cfg.timeout = d
+	}
+}
+
+// AlternateRoot makes TraverseCookieStores() search below root instead of the default locations.
+// Only finders implementing RootFinder are searched.
+func AlternateRoot(root string) TraverseOption {
+	return func(cfg *traverseConfig) {
+		cfg.root = root
+	}
+}
+
+func newTraverseConfig(opts ...TraverseOption) *traverseConfig {
+	cfg := &traverseConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	return cfg
+}
+
+// selectedFinders returns the registered finders allowed by the config.
+// muFinder has to be read locked by the caller.
+func (cfg *traverseConfig) selectedFinders() []CookieStoreFinder {
+	var ret []CookieStoreFinder
+	for browser, finder := range finders {
+		if finder == nil {
+			continue
+		}
+		if len(cfg.browsers) > 0 && !slices.Contains(cfg.browsers, browser) {
+			continue
+		}
+		ret = append(ret, finder)
+	}
+	return ret
+}
+
+func (cfg *traverseConfig) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if cfg.timeout > 0 {
+		return context.WithTimeout(ctx, cfg.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// limitCookieStore wraps the cookie store if concurrency or time limits are configured
+// or the traversal can be cancelled.
+// ctx is the context of the caller, deadline the global deadline.
+func (cfg *traverseConfig) limitCookieStore(ctx context.Context, deadline time.Time, cookieStore CookieStore) CookieStore {
+	if cfg.maxParallel <= 0 && cfg.storeTimeout <= 0 && cfg.timeout <= 0 && ctx.Done() == nil {
+		return cookieStore
+	}
+	if cfg.sem == nil && cfg.maxParallel > 0 {
+		cfg.sem = make(chan struct{}, cfg.maxParallel)
+	}
+	st := &limitedCookieStore{
+		CookieStore: cookieStore,
+		ctx:         ctx,
+		sem:         cfg.sem,
+		timeout:     cfg.storeTimeout,
+	}
+	if cfg.timeout > 0 {
+		st.deadline = deadline
+	}
+	return st
+}
+
+// limitedCookieStore enforces the concurrency limit and timeouts on TraverseCookies().
+type limitedCookieStore struct {
+	CookieStore
+	ctx      context.Context
+	sem      chan struct{} // shared by the cookie stores of a traversal
+	timeout  time.Duration // time spent reading, not waiting for the consumer
+	deadline time.Time
+}
+
+var (
+	_ PersistentCookieStore = (*limitedCookieStore)(nil)
+	_ CookieWriter          = (*limitedCookieStore)(nil)
+	_ CapabilityReporter    = (*limitedCookieStore)(nil)
+)
+
+func (s *limitedCookieStore) TraverseCookies(filters ...Filter) CookieSeq {
+	return func(yield func(*Cookie, error) bool) {
+		ctx := s.ctx
+		if !s.deadline.IsZero() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, s.deadline)
+			defer cancel()
+		}
+		if err := ctx.Err(); err != nil {
+			_ = yield(nil, s.wrapErr(err))
+			return
+		}
+		if s.sem != nil {
+			select {
+			case s.sem <- struct{}{}:
+				defer func() { <-s.sem }()
+			case <-ctx.Done():
+				_ = yield(nil, s.wrapErr(ctx.Err()))
+				return
+			}
+		}
+		// the store timeout is paused while the consumer handles a cookie
+		var timeout <-chan time.Time
+		remaining := s.timeout
+		var timer *time.Timer
+		if s.timeout > 0 {
+			timer = time.NewTimer(remaining)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		started := time.Now()
+
+		type ce struct {
+			c *Cookie
+			e error
+		}
+		ch := make(chan ce)
+		quit := make(chan struct{})
+		defer close(quit)
+		go func() {
+			defer close(ch)
+			for cookie, err := range s.CookieStore.TraverseCookies(filters...) {
+				select {
+				case ch <- ce{c: cookie, e: err}:
+				case <-quit:
+					return
+				}
+			}
+		}()
+		for {
+			// the reading goroutine is left behind if the cookie store hangs
+			select {
+			case <-ctx.Done():
+				_ = yield(nil, s.wrapErr(ctx.Err()))
+				return
+			case <-timeout:
+				_ = yield(nil, s.wrapErr(context.DeadlineExceeded))
+				return
+			case item, ok := <-ch:
+				if !ok {
+					return
+				}
+				if timer != nil {
+					timer.Stop()
+					remaining -= time.Since(started)
+				}
+				if !yield(item.c, item.e) {
+					return
+				}
+				if timer != nil {
+					timer.Reset(remaining)
+					started = time.Now()
+				}
+			}
+		}
+	}
+}
+
+// Unwrap returns the wrapped cookie store.
+func (s *limitedCookieStore) Unwrap() CookieStore {
+	return s.CookieStore
+}
+
+func (s *limitedCookieStore) Persist(sidecar string) error {
+	p, ok := s.CookieStore.(PersistentCookieStore)
+	if !ok {
+		return fmt.Errorf(`cookie store %s %q can't persist cookies`, s.Browser(), s.FilePath())
+	}
+	return p.Persist(sidecar)
+}
+
+func (s *limitedCookieStore) Capabilities() StoreCapabilities {
+	return CapabilitiesOf(s.CookieStore)
+}
+
+func (s *limitedCookieStore) WriteCookies(cookies ...*Cookie) error {
+	w, ok := s.CookieStore.(CookieWriter)
+	if !ok {
+		return ErrNotWritable
+	}
+	return w.WriteCookies(cookies...)
+}
+
+func (s *limitedCookieStore) wrapErr(err error) error {
+	return fmt.Errorf(`cookie store %s %q: %w`, s.Browser(), s.FilePath(), err)
+}
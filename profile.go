@@ -0,0 +1,168 @@
+package kooky
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Profile is a browser profile.
+// Profiles can be listed without reading any cookies.
+type Profile struct {
+	Browser      string    `json:"browser"`
+	Name         string    `json:"name"` // same as BrowserInfo.Profile() of the profile's cookie stores
+	Dir          string    `json:"dir"`
+	IsDefault    bool      `json:"is_default"`
+	DisplayName  string    `json:"display_name,omitempty"`
+	LastUsed     time.Time `json:"last_used,omitzero"`
+	AccountEmail string    `json:"account_email,omitempty"`
+
+	finder CookieStoreFinder
+}
+
+// ProfileFinder is implemented by CookieStoreFinders that are able to
+// list browser profiles without looking for cookie stores.
+type ProfileFinder interface {
+	FindProfiles() ProfileSeq
+}
+
+type ProfileSeq iter.Seq2[*Profile, error]
+
+// FindProfiles() lists the profiles of the browsers with registered finders.
+//
+// Finders not implementing ProfileFinder contribute the profiles of their existing cookie store files.
+func FindProfiles(ctx context.Context, opts ...TraverseOption) []*Profile {
+	var ret []*Profile
+	for p, err := range TraverseProfiles(ctx, opts...) {
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil || p == nil {
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+func TraverseProfiles(ctx context.Context, opts ...TraverseOption) ProfileSeq {
+	cfg := newTraverseConfig(opts...)
+	muFinder.RLock()
+	var seqs []iter.Seq2[*Profile, error]
+	for _, finder := range cfg.selectedFinders() {
+		seqs = append(seqs, iter.Seq2[*Profile, error](profilesOfFinder(ctx, finder)))
+	}
+	muFinder.RUnlock()
+	return ProfileSeq(mergeSeqs(ctx, cfg.maxParallel, nil, seqs...))
+}
+
+func profilesOfFinder(ctx context.Context, finder CookieStoreFinder) ProfileSeq {
+	return func(yield func(*Profile, error) bool) {
+		if pf, ok := finder.(ProfileFinder); ok {
+			for p, err := range pf.FindProfiles() {
+				if ctx.Err() != nil {
+					return
+				}
+				if p != nil {
+					p.finder = finder
+				}
+				if !yield(p, err) {
+					return
+				}
+			}
+			return
+		}
+
+		// fallback: derive profiles from the locations of existing cookie store files
+		type profKey struct{ browser, name, dir string }
+		seen := make(map[profKey]struct{})
+		for st, err := range finder.FindCookieStores() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			if st == nil {
+				continue
+			}
+			fi, err := os.Stat(st.FilePath())
+			st.Close()
+			if err != nil {
+				continue
+			}
+			dir := st.FilePath()
+			if !fi.IsDir() {
+				dir = filepath.Dir(dir)
+			}
+			k := profKey{browser: st.Browser(), name: st.Profile(), dir: dir}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			p := &Profile{
+				Browser:     k.browser,
+				Name:        k.name,
+				Dir:         k.dir,
+				IsDefault:   st.IsDefaultProfile(),
+				DisplayName: k.name,
+				finder:      finder,
+			}
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CookieStores() returns the cookie stores located in the profile directory.
+func (p *Profile) CookieStores() CookieStoreSeq {
+	return func(yield func(CookieStore, error) bool) {
+		if p == nil {
+			_ = yield(nil, errors.New(`nil receiver`))
+			return
+		}
+		finder := p.finder
+		if finder == nil {
+			muFinder.RLock()
+			finder = finders[p.Browser]
+			muFinder.RUnlock()
+		}
+		if finder == nil {
+			_ = yield(nil, errors.New(`no finder registered for browser "`+p.Browser+`"`))
+			return
+		}
+		for st, err := range finder.FindCookieStores() {
+			if err != nil {
+				// errors of other profiles are of no concern here
+				continue
+			}
+			if st == nil {
+				continue
+			}
+			if st.Browser() != p.Browser || !p.contains(st.FilePath()) {
+				st.Close()
+				continue
+			}
+			if !yield(st, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (p *Profile) contains(path string) bool {
+	if p == nil || len(p.Dir) == 0 || len(path) == 0 {
+		return false
+	}
+	dir := filepath.Clean(p.Dir)
+	path = filepath.Clean(path)
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
@@ -0,0 +1,79 @@
+package kooky_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/internal/cookies"
+	"github.com/browserutils/kooky/internal/netscape"
+)
+
+type profileTestFinder struct{ root string }
+
+func (f *profileTestFinder) FindCookieStores() kooky.CookieStoreSeq {
+	return func(yield func(kooky.CookieStore, error) bool) {
+		for _, prof := range []string{`a`, `b`, `missing`} {
+			st := &cookies.CookieJar{
+				CookieStore: &netscape.CookieStore{
+					DefaultCookieStore: cookies.DefaultCookieStore{
+						BrowserStr:           `profiletest`,
+						ProfileStr:           prof,
+						IsDefaultProfileBool: prof == `a`,
+						FileNameStr:          filepath.Join(f.root, prof, `cookies.txt`),
+					},
+				},
+			}
+			if !yield(st, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestFindProfiles(t *testing.T) {
+	root := t.TempDir()
+	for _, prof := range []string{`a`, `b`} {
+		if err := os.MkdirAll(filepath.Join(root, prof), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, prof, `cookies.txt`), []byte("# HTTP Cookie File\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	kooky.RegisterFinder(`profiletest`, &profileTestFinder{root: root})
+
+	ctx := context.Background()
+	profiles := map[string]*kooky.Profile{}
+	for _, p := range kooky.FindProfiles(ctx) {
+		if p.Browser == `profiletest` {
+			profiles[p.Name] = p
+		}
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("got %d profiles, want 2", len(profiles))
+	}
+	if !profiles[`a`].IsDefault || profiles[`b`].IsDefault {
+		t.Error(`wrong default profile`)
+	}
+	if want := filepath.Join(root, `b`); profiles[`b`].Dir != want {
+		t.Errorf("Dir=%q, want %q", profiles[`b`].Dir, want)
+	}
+
+	var n int
+	for st, err := range profiles[`b`].CookieStores() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st.Profile() != `b` {
+			t.Errorf("got cookie store of profile %q", st.Profile())
+		}
+		st.Close()
+		n++
+	}
+	if n != 1 {
+		t.Errorf("got %d cookie stores, want 1", n)
+	}
+}
@@ -0,0 +1,157 @@
+package kooky
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// CookieCmp compares two cookies in the manner of cmp.Compare().
+type CookieCmp func(a, b *Cookie) int
+
+var (
+	ByBrowser CookieCmp = func(a, b *Cookie) int { return cmp.Compare(browserOf(a), browserOf(b)) }
+	ByProfile CookieCmp = func(a, b *Cookie) int { return cmp.Compare(profileOf(a), profileOf(b)) }
+	ByDomain  CookieCmp = func(a, b *Cookie) int { return cmp.Compare(a.Domain, b.Domain) }
+	ByPath    CookieCmp = func(a, b *Cookie) int { return cmp.Compare(a.Path, b.Path) }
+	ByName    CookieCmp = func(a, b *Cookie) int { return cmp.Compare(a.Name, b.Name) }
+	ByExpiry  CookieCmp = func(a, b *Cookie) int { return a.Expires.Compare(b.Expires) }
+)
+
+// SortOrder() chains the comparisons.
+// Later comparisons are used for breaking ties of earlier ones.
+func SortOrder(cmps ...CookieCmp) CookieCmp {
+	return func(a, b *Cookie) int {
+		for _, c := range cmps {
+			if c == nil {
+				continue
+			}
+			if r := c(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+}
+
+// DefaultSortOrder sorts by browser, profile, file path, container, domain, path and name.
+var DefaultSortOrder = SortOrder(
+	ByBrowser,
+	ByProfile,
+	func(a, b *Cookie) int { return cmp.Compare(filePathOf(a), filePathOf(b)) },
+	func(a, b *Cookie) int { return cmp.Compare(a.Container, b.Container) },
+	ByDomain,
+	ByPath,
+	ByName,
+)
+
+// Sorted() collects the cookies of the sequence and yields them sorted.
+// Errors are yielded after the cookies.
+// A nil compare function uses DefaultSortOrder.
+func (s CookieSeq) Sorted(compare CookieCmp) CookieSeq {
+	return func(yield func(*Cookie, error) bool) {
+		if s == nil {
+			return
+		}
+		var (
+			cookies Cookies
+			errs    []error
+		)
+		for cookie, err := range s {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if cookie != nil {
+				cookies = append(cookies, cookie)
+			}
+		}
+		cookies.Sort(compare)
+		for _, cookie := range cookies {
+			if !yield(cookie, nil) {
+				return
+			}
+		}
+		for _, err := range errs {
+			if !yield(nil, err) {
+				return
+			}
+		}
+	}
+}
+
+// Sort() sorts the cookies in place. The sort is stable.
+// A nil compare function uses DefaultSortOrder.
+func (c Cookies) Sort(compare CookieCmp) {
+	if compare == nil {
+		compare = DefaultSortOrder
+	}
+	slices.SortStableFunc(c, func(a, b *Cookie) int {
+		// nil cookies last
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		case b == nil:
+			return -1
+		}
+		return compare(a, b)
+	})
+}
+
+// GroupKey returns the key under which a cookie is grouped.
+type GroupKey func(*Cookie) string
+
+var (
+	// GroupByDomain groups by cookie domain without a leading dot.
+	GroupByDomain GroupKey = func(c *Cookie) string { return strings.TrimPrefix(c.Domain, `.`) }
+	// GroupBySite groups by registrable domain (eTLD+1) of the cookie domain.
+	GroupBySite GroupKey = func(c *Cookie) string {
+		domain := strings.TrimPrefix(c.Domain, `.`)
+		if site, err := publicsuffix.EffectiveTLDPlusOne(domain); err == nil {
+			return site
+		}
+		return domain
+	}
+	GroupByBrowser GroupKey = func(c *Cookie) string { return browserOf(c) }
+)
+
+// GroupBy() groups the cookies by key. The cookie order is retained within the groups.
+func (c Cookies) GroupBy(key GroupKey) map[string]Cookies {
+	ret := make(map[string]Cookies)
+	if key == nil {
+		return ret
+	}
+	for _, cookie := range c {
+		if cookie == nil {
+			continue
+		}
+		k := key(cookie)
+		ret[k] = append(ret[k], cookie)
+	}
+	return ret
+}
+
+func browserOf(c *Cookie) string {
+	if c == nil || c.Browser == nil {
+		return ``
+	}
+	return c.Browser.Browser()
+}
+
+func profileOf(c *Cookie) string {
+	if c == nil || c.Browser == nil {
+		return ``
+	}
+	return c.Browser.Profile()
+}
+
+func filePathOf(c *Cookie) string {
+	if c == nil || c.Browser == nil {
+		return ``
+	}
+	return c.Browser.FilePath()
+}
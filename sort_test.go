@@ -0,0 +1,73 @@
+package kooky
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSorted(t *testing.T) {
+	now := time.Now()
+	cookies := Cookies{
+		{Cookie: http.Cookie{Domain: `b.example.com`, Name: `x`, Expires: now.Add(time.Hour)}},
+		{Cookie: http.Cookie{Domain: `.example.com`, Name: `y`, Expires: now}},
+		{Cookie: http.Cookie{Domain: `b.example.com`, Name: `a`, Expires: now.Add(2 * time.Hour)}},
+		{Cookie: http.Cookie{Domain: `example.org`, Name: `z`}},
+	}
+	seq := func(yield func(*Cookie, error) bool) {
+		for i, c := range cookies {
+			if i == 1 && !yield(nil, errors.New(`test`)) {
+				return
+			}
+			if !yield(c, nil) {
+				return
+			}
+		}
+	}
+
+	var names []string
+	var errs int
+	for c, err := range CookieSeq(seq).Sorted(SortOrder(ByDomain, ByName)) {
+		if err != nil {
+			errs++
+			continue
+		}
+		if errs > 0 {
+			t.Error(`cookie yielded after error`)
+		}
+		names = append(names, c.Name)
+	}
+	if want := []string{`y`, `a`, `x`, `z`}; !slices.Equal(names, want) {
+		t.Errorf("got order %v, want %v", names, want)
+	}
+	if errs != 1 {
+		t.Errorf("got %d errors, want 1", errs)
+	}
+
+	names = nil
+	for c := range CookieSeq(seq).Sorted(ByExpiry).OnlyCookies() {
+		names = append(names, c.Name)
+	}
+	if want := []string{`z`, `y`, `x`, `a`}; !slices.Equal(names, want) {
+		t.Errorf("got order %v, want %v", names, want)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	cookies := Cookies{
+		{Cookie: http.Cookie{Domain: `.example.com`, Name: `a`}},
+		{Cookie: http.Cookie{Domain: `example.com`, Name: `b`}},
+		{Cookie: http.Cookie{Domain: `www.example.com`, Name: `c`}},
+		{Cookie: http.Cookie{Domain: `foo.co.uk`, Name: `d`}},
+	}
+	byDomain := cookies.GroupBy(GroupByDomain)
+	if len(byDomain) != 3 || len(byDomain[`example.com`]) != 2 {
+		t.Errorf("wrong domain grouping: %v", byDomain)
+	}
+	bySite := cookies.GroupBy(GroupBySite)
+	if len(bySite) != 2 || len(bySite[`example.com`]) != 3 || len(bySite[`foo.co.uk`]) != 1 {
+		t.Errorf("wrong site grouping: %v", bySite)
+	}
+}
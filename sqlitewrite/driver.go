@@ -0,0 +1,7 @@
+//go:build !js && !wasip1 && !plan9
+
+package sqlitewrite
+
+import (
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
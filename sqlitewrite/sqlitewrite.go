@@ -0,0 +1,8 @@
+// Package sqlitewrite enables writing to the SQLite cookie databases
+// of Chromium and Firefox based browsers:
+//
+//	import _ "github.com/browserutils/kooky/sqlitewrite"
+//
+// It registers the pure Go SQLite driver modernc.org/sqlite, which is large
+// and not available on every platform. Without it these cookie stores are read-only.
+package sqlitewrite
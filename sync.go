@@ -0,0 +1,130 @@
+package kooky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrNotWritable is returned for cookie stores without a CookieWriter implementation.
+var ErrNotWritable = errors.New(`cookie store is not writable`)
+
+// SyncOptions configures Sync().
+type SyncOptions struct {
+	// Filters select the copied cookies.
+	Filters []Filter
+	// DryRun only reports what would be written.
+	DryRun bool
+}
+
+// SyncReport lists the result of Sync().
+type SyncReport struct {
+	Written Cookies
+	Skipped []SkippedCookie
+	// Errors of the source like cookies with undecryptable values, those cookies are not copied.
+	Errors []error
+}
+
+// SkippedCookie is a cookie not copied by Sync() together with the reason.
+type SkippedCookie struct {
+	Cookie *Cookie
+	Reason string
+}
+
+// Sync() copies the cookies of src into the cookie store dst which has to be writable,
+// see CapabilitiesOf(). This is also checked for dry runs.
+//
+// The cookies are converted for the capabilities of the destination:
+// Firefox containers are only kept if the destination supports them,
+// partitioned (CHIPS) cookies keep their partition key (top-level site)
+// and the SameSite attribute is normalized.
+// Cookie values are decrypted when read and encrypted by the writer of the destination.
+// Cookies which can't be represented in the destination are skipped and listed in the report,
+// errors of the source are collected in the report as well.
+func Sync(ctx context.Context, src CookieSeq, dst CookieStore, opts *SyncOptions) (*SyncReport, error) {
+	if src == nil || dst == nil {
+		return nil, errors.New(`no source or destination`)
+	}
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	caps := CapabilitiesOf(dst)
+	w, ok := dst.(CookieWriter)
+	if !ok || !caps.Writable {
+		return nil, fmt.Errorf(`%w: %s %q`, ErrNotWritable, dst.Browser(), dst.FilePath())
+	}
+
+	report := &SyncReport{}
+	now := time.Now()
+	for cookie, err := range src.Filter(ctx, opts.Filters...) {
+		if err != nil {
+			// errors like undecryptable values of single cookies shouldn't stop the copying
+			report.Errors = append(report.Errors, err)
+			continue
+		}
+		if cookie == nil {
+			continue
+		}
+		c, reason := convertCookie(cookie, caps, now)
+		if len(reason) > 0 {
+			report.Skipped = append(report.Skipped, SkippedCookie{Cookie: cookie, Reason: reason})
+			continue
+		}
+		c.Browser = dst
+		report.Written = append(report.Written, c)
+	}
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	if opts.DryRun || len(report.Written) == 0 {
+		return report, nil
+	}
+	if err := w.WriteCookies(report.Written...); err != nil {
+		return report, fmt.Errorf(`writing to %s %q: %w`, dst.Browser(), dst.FilePath(), err)
+	}
+	return report, nil
+}
+
+// convertCookie returns a copy of the cookie for a cookie store with the capabilities caps
+// or the reason why the cookie can't be copied.
+func convertCookie(cookie *Cookie, caps StoreCapabilities, now time.Time) (*Cookie, string) {
+	if len(cookie.Domain) == 0 {
+		return nil, `no domain`
+	}
+	if !cookie.Expires.IsZero() && cookie.Expires.Before(now) {
+		return nil, `expired`
+	}
+	if cookie.Expires.IsZero() && caps.PersistentOnly {
+		return nil, `session cookies not supported by destination`
+	}
+	if len(cookie.Container) > 0 && !caps.Containers {
+		return nil, fmt.Sprintf(`container %q not supported by destination`, cookie.Container)
+	}
+	if cookie.Partitioned {
+		if !caps.Partitions {
+			return nil, `partitioned cookies not supported by destination`
+		}
+		if len(cookie.PartitionKey) == 0 {
+			return nil, `partitioned cookie without partition key`
+		}
+	}
+	if cookie.SameSite == http.SameSiteNoneMode && !cookie.Secure {
+		// rejected by current browsers
+		return nil, `SameSite=None without Secure`
+	}
+
+	c := *cookie
+	c.MaxAge = 0
+	c.Raw = ``
+	c.Unparsed = nil
+	if c.SameSite == http.SameSiteDefaultMode {
+		// same meaning: attribute not set
+		c.SameSite = 0
+	}
+	if !c.Partitioned {
+		c.PartitionKey = ``
+	}
+	return &c, ``
+}
@@ -0,0 +1,110 @@
+package kooky
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type syncTestStore struct {
+	http.CookieJar
+	caps    StoreCapabilities
+	written []*Cookie
+}
+
+func (s *syncTestStore) SubJar(context.Context, ...Filter) (http.CookieJar, error) { return nil, nil }
+func (s *syncTestStore) TraverseCookies(...Filter) CookieSeq                       { return nil }
+func (s *syncTestStore) Browser() string                                           { return `test` }
+func (s *syncTestStore) Profile() string                                           { return `` }
+func (s *syncTestStore) IsDefaultProfile() bool                                    { return true }
+func (s *syncTestStore) FilePath() string                                          { return `` }
+func (s *syncTestStore) Close() error                                              { return nil }
+func (s *syncTestStore) Capabilities() StoreCapabilities                           { return s.caps }
+func (s *syncTestStore) WriteCookies(cookies ...*Cookie) error {
+	s.written = append(s.written, cookies...)
+	return nil
+}
+
+func TestSync(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	src := Cookies{
+		{Cookie: http.Cookie{Domain: `.example.com`, Name: `plain`, Expires: future, SameSite: http.SameSiteDefaultMode}},
+		{Cookie: http.Cookie{Domain: `.example.com`, Name: `expired`, Expires: time.Now().Add(-time.Hour)}},
+		{Cookie: http.Cookie{Domain: `.example.com`, Name: `container`, Expires: future}, Container: `Work`},
+		{Cookie: http.Cookie{Domain: `.example.com`, Name: `chips`, Expires: future, Secure: true, Partitioned: true}, PartitionKey: `https://example.org`},
+		{Cookie: http.Cookie{Domain: `.example.com`, Name: `insecure`, SameSite: http.SameSiteNoneMode}},
+		{Cookie: http.Cookie{Domain: `.example.com`, Name: `session`}},
+	}
+	seq := func(yield func(*Cookie, error) bool) {
+		for _, c := range src {
+			if !yield(c, nil) {
+				return
+			}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		caps    StoreCapabilities
+		written []string
+	}{
+		{name: `chromium`, caps: StoreCapabilities{Writable: true, Partitions: true}, written: []string{`plain`, `chips`, `session`}},
+		{name: `firefox`, caps: StoreCapabilities{Writable: true, Containers: true, Partitions: true, PersistentOnly: true}, written: []string{`plain`, `container`, `chips`}},
+		{name: `netscape`, caps: StoreCapabilities{Writable: true}, written: []string{`plain`, `session`}},
+	}
+	for _, tt := range tests {
+		dst := &syncTestStore{caps: tt.caps}
+		report, err := Sync(context.Background(), seq, dst, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, c := range dst.written {
+			names = append(names, c.Name)
+			if c.Browser != dst {
+				t.Errorf("%s: cookie %q not assigned to destination", tt.name, c.Name)
+			}
+			if c.SameSite == http.SameSiteDefaultMode {
+				t.Errorf("%s: cookie %q: SameSite not normalized", tt.name, c.Name)
+			}
+		}
+		if len(names) != len(tt.written) || len(report.Skipped) != len(src)-len(tt.written) {
+			t.Errorf("%s: got written %v, %d skipped; want written %v", tt.name, names, len(report.Skipped), tt.written)
+			continue
+		}
+		for i := range names {
+			if names[i] != tt.written[i] {
+				t.Errorf("%s: got written %v, want %v", tt.name, names, tt.written)
+				break
+			}
+		}
+	}
+
+	// source errors are reported
+	errDecrypt := errors.New(`decryption failed`)
+	errSeq := func(yield func(*Cookie, error) bool) {
+		if yield(nil, errDecrypt) {
+			yield(src[0], nil)
+		}
+	}
+	report, err := Sync(context.Background(), errSeq, &syncTestStore{caps: StoreCapabilities{Writable: true}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Written) != 1 || len(report.Errors) != 1 || !errors.Is(report.Errors[0], errDecrypt) {
+		t.Errorf("got %d written, errors %v; want 1 written and the source error", len(report.Written), report.Errors)
+	}
+
+	readOnly := struct{ CookieStore }{&syncTestStore{}}
+	_, err = Sync(context.Background(), seq, readOnly, nil)
+	if !errors.Is(err, ErrNotWritable) {
+		t.Errorf("got error %v, want ErrNotWritable", err)
+	}
+	// a CookieWriter wrapping a read-only store
+	_, err = Sync(context.Background(), seq, &syncTestStore{}, &SyncOptions{DryRun: true})
+	if !errors.Is(err, ErrNotWritable) {
+		t.Errorf("dry run: got error %v, want ErrNotWritable", err)
+	}
+}
@@ -0,0 +1,329 @@
+package kooky
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+	"golang.org/x/sync/singleflight"
+)
+
+// TransportOptions configures a Transport.
+type TransportOptions struct {
+	// Stores are the cookie stores read by the Transport.
+	// If empty, the cookie stores found with TraverseCookieStores() are used.
+	Stores []CookieStore
+	// TraverseOptions are passed to TraverseCookieStores() if Stores is empty.
+	TraverseOptions []TraverseOption
+	// Filters select the cookies sent with requests.
+	Filters []Filter
+	// RetryOnAuthFailure resends a request once if the response status is 401 or 403
+	// and the reread cookie stores provide different cookies.
+	// Only requests without body or with Request.GetBody set are retried.
+	RetryOnAuthFailure bool
+	// AuthFailureRefreshInterval is the minimum time between rereads of the cookie stores
+	// caused by 401 and 403 responses, 10 seconds if zero.
+	AuthFailureRefreshInterval time.Duration
+}
+
+const defaultAuthFailureRefreshInterval = 10 * time.Second
+
+// Transport is an http.RoundTripper adding cookies from browser cookie stores to requests.
+//
+// The cookie stores are reread when their files are modified
+// or when a response has the status 401 (Unauthorized) or 403 (Forbidden),
+// at most once per TransportOptions.AuthFailureRefreshInterval.
+// Cookies set by responses are kept unless a cookie store file was modified later.
+type Transport struct {
+	Base http.RoundTripper // http.DefaultTransport if nil
+
+	opts    TransportOptions
+	rereads singleflight.Group
+
+	mu          sync.Mutex // guards the fields below
+	stores      []CookieStore
+	jar         *cookiejar.Jar
+	mtimes      map[string]time.Time
+	incomplete  bool      // the last read was cut short
+	forcedAt    time.Time // last reread caused by 401 or 403
+	respCookies map[string]responseCookie
+}
+
+// responseCookie is a cookie set by a response.
+type responseCookie struct {
+	url    *url.URL
+	cookie *http.Cookie
+	setAt  time.Time
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+func NewTransport(base http.RoundTripper, opts *TransportOptions) *Transport {
+	t := &Transport{Base: base}
+	if opts != nil {
+		t.opts = *opts
+	}
+	return t
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t == nil {
+		closeBody(req)
+		return nil, errors.New(`nil receiver`)
+	}
+	if req == nil || req.URL == nil {
+		closeBody(req)
+		return nil, errors.New(`request without URL`)
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if err := t.refresh(req.Context(), false); err != nil {
+		closeBody(req)
+		return nil, err
+	}
+	cookieHeader := t.cookieHeader(req.URL)
+	resp, err := base.RoundTrip(withCookies(req, cookieHeader))
+	if err != nil {
+		return nil, err
+	}
+	t.setCookies(req.URL, resp.Cookies())
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return resp, nil
+	}
+
+	// the browser session might have been renewed
+	if err := t.refresh(req.Context(), true); err != nil {
+		return resp, nil
+	}
+	if !t.opts.RetryOnAuthFailure || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return resp, nil
+	}
+	newCookieHeader := t.cookieHeader(req.URL)
+	if newCookieHeader == cookieHeader {
+		return resp, nil
+	}
+	retryReq := req
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retryReq = req.Clone(req.Context())
+		retryReq.Body = body
+	}
+	resp.Body.Close()
+	resp, err = base.RoundTrip(withCookies(retryReq, newCookieHeader))
+	if err != nil {
+		return nil, err
+	}
+	t.setCookies(req.URL, resp.Cookies())
+	return resp, nil
+}
+
+// closeBody closes the request body, http.RoundTripper has to do so even on errors.
+func closeBody(req *http.Request) {
+	if req != nil && req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// withCookies returns a copy of the request with the cookies appended to the Cookie header.
+// The original request is not modified as required by http.RoundTripper.
+func withCookies(req *http.Request, cookieHeader string) *http.Request {
+	if len(cookieHeader) == 0 {
+		return req
+	}
+	r := req.Clone(req.Context())
+	if existing := r.Header.Get(`Cookie`); len(existing) > 0 {
+		cookieHeader = existing + `; ` + cookieHeader
+	}
+	r.Header.Set(`Cookie`, cookieHeader)
+	return r
+}
+
+func (t *Transport) cookieHeader(u *url.URL) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.jar == nil {
+		return ``
+	}
+	var parts []string
+	for _, c := range t.jar.Cookies(u) {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, `; `)
+}
+
+func (t *Transport) setCookies(u *url.URL, cookies []*http.Cookie) {
+	if len(cookies) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.jar == nil {
+		return
+	}
+	t.jar.SetCookies(u, cookies)
+	// remembered for rereads
+	if t.respCookies == nil {
+		t.respCookies = make(map[string]responseCookie)
+	}
+	now := time.Now()
+	for _, c := range cookies {
+		domain := c.Domain
+		if len(domain) == 0 {
+			domain = u.Hostname()
+		}
+		t.respCookies[domain+"\x00"+c.Path+"\x00"+c.Name] = responseCookie{url: u, cookie: c, setAt: now}
+	}
+}
+
+// refresh rereads the cookie stores if forced, not yet read or if their files were modified.
+//
+// The cookie stores are read by a single goroutine without holding t.mu,
+// concurrent callers wait for its result or until their context is done.
+func (t *Transport) refresh(ctx context.Context, force bool) error {
+	interval := t.opts.AuthFailureRefreshInterval
+	if interval <= 0 {
+		interval = defaultAuthFailureRefreshInterval
+	}
+	t.mu.Lock()
+	if force {
+		if time.Since(t.forcedAt) < interval {
+			// a burst of 401/403 responses doesn't cause a reread each
+			force = false
+		} else {
+			t.forcedAt = time.Now()
+		}
+	}
+	stores, mtimes, current := t.stores, t.mtimes, t.jar != nil && !t.incomplete
+	t.mu.Unlock()
+	if !force && current && maps.EqualFunc(fileMtimes(stores), mtimes, time.Time.Equal) {
+		return nil
+	}
+
+	// the reread is shared, it is not cancelled with the request which started it
+	ch := t.rereads.DoChan(`reread`, func() (any, error) {
+		return nil, t.reread(context.WithoutCancel(ctx), force)
+	})
+	select {
+	case res := <-ch:
+		return res.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reread reads the cookie stores into a new cookie jar.
+// The cookie stores are searched again if research is set.
+//
+// Results cut short by timeouts are used, but reread on the next request.
+func (t *Transport) reread(ctx context.Context, research bool) error {
+	t.mu.Lock()
+	stores := t.stores
+	incomplete := t.incomplete
+	t.mu.Unlock()
+
+	var cutShort bool
+	isTimeout := func(err error) bool {
+		return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+	}
+	if research || stores == nil || incomplete {
+		stores = t.opts.Stores
+		if len(stores) == 0 {
+			for st, err := range TraverseCookieStores(ctx, t.opts.TraverseOptions...) {
+				if err != nil {
+					cutShort = cutShort || isTimeout(err)
+					continue
+				}
+				if st != nil {
+					stores = append(stores, st)
+				}
+			}
+		}
+		if stores == nil {
+			// don't search again on each request
+			stores = []CookieStore{}
+		}
+	}
+	mtimes := fileMtimes(stores)
+
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	if err != nil {
+		return err
+	}
+	for _, st := range stores {
+		// reopen for current file contents
+		_ = st.Close()
+		for cookie, err := range st.TraverseCookies(t.opts.Filters...) {
+			if err != nil {
+				cutShort = cutShort || isTimeout(err)
+				continue
+			}
+			setCookie(jar, cookie)
+		}
+		_ = st.Close()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// response cookies are newer than the cookie stores unless a file was modified afterwards
+	var modified time.Time
+	for _, mtime := range mtimes {
+		if mtime.After(modified) {
+			modified = mtime
+		}
+	}
+	for key, rc := range t.respCookies {
+		if rc.setAt.Before(modified) {
+			delete(t.respCookies, key)
+			continue
+		}
+		jar.SetCookies(rc.url, []*http.Cookie{rc.cookie})
+	}
+	t.stores = stores
+	t.jar = jar
+	t.mtimes = mtimes
+	t.incomplete = cutShort
+	return nil
+}
+
+// fileMtimes returns the modification times of the cookie store files.
+func fileMtimes(stores []CookieStore) map[string]time.Time {
+	mtimes := make(map[string]time.Time, len(stores))
+	for _, st := range stores {
+		if fi, err := os.Stat(st.FilePath()); err == nil {
+			mtimes[st.FilePath()] = fi.ModTime()
+		}
+	}
+	return mtimes
+}
+
+// setCookie stores the cookie in the jar under its own domain and path.
+func setCookie(jar http.CookieJar, cookie *Cookie) {
+	c := cookie.Cookie
+	host := strings.TrimPrefix(c.Domain, `.`)
+	if len(host) == 0 {
+		return
+	}
+	if !strings.HasPrefix(c.Domain, `.`) {
+		// host-only cookie
+		c.Domain = ``
+	}
+	scheme := `http`
+	if c.Secure {
+		scheme = `https`
+	}
+	jar.SetCookies(&url.URL{Scheme: scheme, Host: host, Path: c.Path}, []*http.Cookie{&c})
+}
@@ -0,0 +1,175 @@
+package kooky_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/browserutils/kooky"
+	"github.com/browserutils/kooky/browser/netscape"
+	"github.com/browserutils/kooky/internal/cookies"
+)
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie(`session`)
+		if err != nil || c.Value != `valid` {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `ok`)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	cookieFile := filepath.Join(t.TempDir(), `cookies.txt`)
+	writeSession := func(value string, mtime time.Time) {
+		t.Helper()
+		exp := time.Now().Add(24 * time.Hour).Unix()
+		line := fmt.Sprintf("# HTTP Cookie File\n%s\tFALSE\t/\tFALSE\t%d\tsession\t%s\n", u.Hostname(), exp, value)
+		if err := os.WriteFile(cookieFile, []byte(line), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(cookieFile, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	writeSession(`valid`, now.Add(-time.Hour))
+
+	st, err := netscape.CookieStore(cookieFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	client := &http.Client{Transport: kooky.NewTransport(nil, &kooky.TransportOptions{
+		Stores:                     []kooky.CookieStore{st},
+		RetryOnAuthFailure:         true,
+		AuthFailureRefreshInterval: time.Nanosecond,
+	})}
+	get := func() int {
+		t.Helper()
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := get(); code != http.StatusOK {
+		t.Fatalf("got status %d, want 200", code)
+	}
+	// modified file is reread
+	writeSession(`expired`, now.Add(-time.Minute))
+	if code := get(); code != http.StatusUnauthorized {
+		t.Fatalf("got status %d, want 401", code)
+	}
+	// session renewed without mtime change: reread and retry on 401
+	writeSession(`valid`, now.Add(-time.Minute))
+	if code := get(); code != http.StatusOK {
+		t.Fatalf("got status %d, want 200 after retry", code)
+	}
+}
+
+type countingStore struct {
+	cookies.DefaultCookieStore
+	mu         sync.Mutex
+	traversals int
+}
+
+func (s *countingStore) TraverseCookies(filters ...kooky.Filter) kooky.CookieSeq {
+	s.mu.Lock()
+	s.traversals++
+	s.mu.Unlock()
+	return func(yield func(*kooky.Cookie, error) bool) {}
+}
+
+func TestTransportAuthFailureRefresh(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case `/login`:
+			http.SetCookie(w, &http.Cookie{Name: `resp`, Value: `1`, Path: `/`})
+		case `/check`:
+			if _, err := r.Cookie(`resp`); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	st := &countingStore{}
+	st.FileNameStr = filepath.Join(t.TempDir(), `missing`)
+	client := &http.Client{Transport: kooky.NewTransport(nil, &kooky.TransportOptions{
+		Stores: []kooky.CookieStore{&cookies.CookieJar{CookieStore: st}},
+	})}
+	get := func(path string) int {
+		t.Helper()
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	get(`/login`)
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			get(`/forbidden`)
+		}()
+	}
+	wg.Wait()
+	st.mu.Lock()
+	traversals := st.traversals
+	st.mu.Unlock()
+	// the initial read and one reread for the burst of 403 responses
+	if traversals != 2 {
+		t.Errorf("cookie store read %d times, want 2", traversals)
+	}
+	// the cookie set by the response survives the reread
+	if code := get(`/check`); code != http.StatusOK {
+		t.Errorf("got status %d, want 200", code)
+	}
+}
+
+func TestTransportClosesBody(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	body := &closeRecorder{Reader: strings.NewReader(`x`)}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, `http://127.0.0.1/`, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := kooky.NewTransport(nil, &kooky.TransportOptions{TraverseOptions: []kooky.TraverseOption{kooky.OnlyBrowsers(`none`)}})
+	if _, err := tr.RoundTrip(req); err == nil {
+		t.Fatal(`no error for cancelled request`)
+	}
+	if !body.closed {
+		t.Error(`request body not closed`)
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}